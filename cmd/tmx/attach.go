@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// runAttach 处理 tmx attach <目标> [-r] [-d] [-g]
+// 目标可以是会话、窗口（dev:1）或窗格（dev:1.0）
+func runAttach(args []string) int {
+	target := ""
+	var opts tmux.AttachOptions
+	for _, arg := range args {
+		switch arg {
+		case "-r", "--read-only":
+			opts.ReadOnly = true
+		case "-d", "--detach-others":
+			opts.DetachOthers = true
+		case "-g", "--group":
+			opts.Grouped = true
+		default:
+			if strings.HasPrefix(arg, "-") || target != "" {
+				fmt.Println(i18n.T("cli.unknown_arg", arg))
+				return 1
+			}
+			target = arg
+		}
+	}
+	if target == "" {
+		fmt.Println(i18n.T("attach.usage"))
+		return 1
+	}
+
+	if err := tmux.NewManager().Attach(target, opts); err != nil {
+		fmt.Println(i18n.T("cli.attach_error", err))
+		return 1
+	}
+	return 0
+}
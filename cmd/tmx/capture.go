@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// runCapture 处理 tmx capture <目标> [-e] [-c] [-o 文件]
+// 默认保存到配置的导出目录，-o - 输出到标准输出，-c 复制到剪贴板
+func runCapture(settings *config.Settings, args []string) int {
+	target, output := "", ""
+	escapes, clipboard := false, false
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; arg {
+		case "-e", "--escapes":
+			escapes = true
+		case "-c", "--clipboard":
+			clipboard = true
+		case "-o", "--output":
+			if i+1 >= len(args) {
+				fmt.Println(i18n.T("capture.usage"))
+				return 1
+			}
+			output = args[i+1]
+			i++
+		default:
+			if strings.HasPrefix(arg, "-") || target != "" {
+				fmt.Println(i18n.T("cli.unknown_arg", arg))
+				return 1
+			}
+			target = arg
+		}
+	}
+	if target == "" {
+		fmt.Println(i18n.T("capture.usage"))
+		return 1
+	}
+
+	manager := tmux.NewManager()
+	if output == "" && !clipboard {
+		dir, err := settings.ExportDir()
+		if err == nil {
+			output, err = manager.ExportScrollback(target, dir, escapes)
+		}
+		if err != nil {
+			fmt.Println(i18n.T("cli.error", err))
+			return 1
+		}
+		fmt.Println(i18n.T("capture.saved", output))
+		return 0
+	}
+
+	content, err := manager.CaptureScrollback(target, escapes)
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	if clipboard {
+		if err := manager.CopyToClipboard(content, nil); err != nil {
+			fmt.Println(i18n.T("cli.error", err))
+			return 1
+		}
+		fmt.Fprintln(os.Stderr, i18n.N("capture.copied", strings.Count(content, "\n")))
+	}
+	switch output {
+	case "":
+	case "-":
+		fmt.Print(content)
+	default:
+		if err := os.WriteFile(output, []byte(content), 0o644); err != nil {
+			fmt.Println(i18n.T("cli.error", err))
+			return 1
+		}
+		fmt.Println(i18n.T("capture.saved", output))
+	}
+	return 0
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/DreamCats/tmuxmanager/internal/archive"
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/shell"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/DreamCats/tmuxmanager/internal/ui"
+)
+
+// commands 描述 tmx 的子命令和参数，新增子命令时同步更新以保持补全完整
+var commands = []shell.Command{
+	{Name: "init", Args: []shell.ArgKind{shell.ArgShell}, Flags: []shell.Flag{
+		{Name: "--auto-attach"},
+		{Name: "--alias", Value: shell.ArgText},
+		{Name: "--no-alias"},
+	}},
+	{Name: "ls", Flags: []shell.Flag{
+		{Name: "--format", Value: shell.ArgText, Choices: []string{"text", "json"}},
+		{Name: "--sort", Value: shell.ArgText, Choices: []string{"cpu", "mem"}},
+		{Name: "--dirty"},
+	}},
+	{Name: "ps", Args: []shell.ArgKind{shell.ArgTarget}, Variadic: true, Flags: []shell.Flag{
+		{Name: "--port", Value: shell.ArgText},
+	}},
+	{Name: "hibernate", Args: []shell.ArgKind{shell.ArgSession}, Variadic: true},
+	{Name: "revive", Args: []shell.ArgKind{shell.ArgArchive}, Variadic: true},
+	{Name: "reap", Flags: []shell.Flag{
+		{Name: "--dry-run"},
+	}},
+	{Name: "attach", Args: []shell.ArgKind{shell.ArgTarget}, Flags: []shell.Flag{
+		{Name: "--read-only"},
+		{Name: "--detach-others"},
+		{Name: "--group"},
+	}},
+	{Name: "send", Args: []shell.ArgKind{shell.ArgTarget}, Variadic: true, Flags: []shell.Flag{
+		{Name: "--keys"},
+		{Name: "--yes"},
+		{Name: "--"},
+	}},
+	{Name: "grep", Args: []shell.ArgKind{shell.ArgText}, Flags: []shell.Flag{
+		{Name: "--ignore-case"},
+		{Name: "--context", Value: shell.ArgText},
+		{Name: "--all"},
+	}},
+	{Name: "capture", Args: []shell.ArgKind{shell.ArgTarget}, Flags: []shell.Flag{
+		{Name: "--escapes"},
+		{Name: "--clipboard"},
+		{Name: "--output", Value: shell.ArgText},
+	}},
+	{Name: "record", Args: []shell.ArgKind{shell.ArgTarget}, Variadic: true, Flags: []shell.Flag{
+		{Name: "--cast"},
+		{Name: "--stop"},
+	}},
+	{Name: "replay", Args: []shell.ArgKind{shell.ArgText}},
+	{Name: "watch", Args: []shell.ArgKind{shell.ArgTarget}, Variadic: true, Flags: []shell.Flag{
+		{Name: "--stop"},
+	}},
+	{Name: "notify", Subcommands: []shell.Command{{Name: "test"}}},
+	{Name: "completion", Args: []shell.ArgKind{shell.ArgShell}},
+	{Name: "doctor"},
+	{Name: "status", Args: []shell.ArgKind{shell.ArgSession}},
+	{Name: "theme", Subcommands: []shell.Command{
+		{Name: "preview", Args: []shell.ArgKind{shell.ArgTheme}, Variadic: true},
+	}},
+	{Name: "--install", Flags: []shell.Flag{{Name: "--dry-run"}, {Name: "--all-shells"}}},
+	{Name: "--uninstall", Flags: []shell.Flag{{Name: "--dry-run"}}},
+	{Name: "--help"},
+	{Name: "--version"},
+	{Name: "--popup", Hidden: true, Flags: []shell.Flag{{Name: "--client", Value: shell.ArgText}}},
+	{Name: "__complete", Hidden: true},
+	{Name: "__record", Hidden: true},
+	{Name: "__watch", Hidden: true},
+}
+
+// completionSource 从运行中的 tmux 和配置文件读取补全数据
+type completionSource struct {
+	manager  *tmux.Manager
+	settings *config.Settings
+}
+
+func (s completionSource) Sessions() []string {
+	sessions, err := s.manager.ListSessions()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		names = append(names, session.Name)
+	}
+	return names
+}
+
+func (s completionSource) Windows() []string {
+	windows, err := s.manager.ListWindows("")
+	if err != nil {
+		return nil
+	}
+	targets := make([]string, 0, len(windows))
+	for _, w := range windows {
+		targets = append(targets, w.Target())
+	}
+	return targets
+}
+
+func (s completionSource) Themes() []string {
+	return ui.ThemeNames(s.settings.Themes)
+}
+
+func (s completionSource) Archives() []string {
+	dir, err := s.settings.ArchiveDir()
+	if err != nil {
+		return nil
+	}
+	sessions, _ := archive.List(dir)
+	var names []string
+	for _, session := range sessions {
+		if !slices.Contains(names, session.Name) {
+			names = append(names, session.Name)
+		}
+	}
+	return names
+}
+
+// runCompletion 处理 tmx completion <shell>，输出补全脚本
+func runCompletion(args []string) int {
+	if len(args) == 0 {
+		fmt.Println(i18n.T("completion.usage"))
+		return 1
+	}
+	script, err := shell.Completion(args[0])
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	fmt.Print(script)
+	return 0
+}
+
+// runComplete 处理隐藏的 tmx __complete <单词...>，每行输出一个候选
+// 由补全脚本调用，最后一个参数是正在输入的单词
+func runComplete(settings *config.Settings, args []string) int {
+	src := completionSource{manager: tmux.NewManager(), settings: settings}
+	for _, candidate := range shell.Complete(commands, args, src) {
+		fmt.Println(candidate)
+	}
+	return 0
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"os"
+
+	"github.com/DreamCats/tmuxmanager/internal/doctor"
+)
+
+// runDoctor 处理 tmx doctor，有失败项时返回非零退出码
+func runDoctor() int {
+	if doctor.Print(os.Stdout, doctor.Run()) > 0 {
+		return 1
+	}
+	return 0
+}
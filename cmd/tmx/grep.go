@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// runGrep 处理 tmx grep [-i] [-C 行数] [--all] <正则>
+// 在所有窗格的滚动历史中搜索，输出格式与 grep 相同：目标:行号: 内容
+func runGrep(args []string) int {
+	pattern := ""
+	ignoreCase := false
+	opts := tmux.SearchOptions{MaxLines: tmux.DefaultSearchLines}
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; arg {
+		case "-i", "--ignore-case":
+			ignoreCase = true
+		case "-a", "--all":
+			opts.MaxLines = 0
+		case "-C", "--context":
+			if i+1 >= len(args) {
+				fmt.Println(i18n.T("grep.usage", tmux.DefaultSearchLines))
+				return 1
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 0 {
+				fmt.Println(i18n.T("cli.unknown_arg", args[i+1]))
+				return 1
+			}
+			opts.Context = n
+			i++
+		default:
+			if strings.HasPrefix(arg, "-") || pattern != "" {
+				fmt.Println(i18n.T("cli.unknown_arg", arg))
+				return 1
+			}
+			pattern = arg
+		}
+	}
+	if pattern == "" {
+		fmt.Println(i18n.T("grep.usage", tmux.DefaultSearchLines))
+		return 1
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		fmt.Println(i18n.T("grep.bad_regex", err))
+		return 1
+	}
+
+	matches, err := tmux.NewManager().SearchPanes(re, opts)
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	if len(matches) == 0 {
+		return 1
+	}
+
+	for i, match := range matches {
+		if opts.Context > 0 && i > 0 {
+			fmt.Println("--")
+		}
+		target := match.Pane.Target()
+		first := match.Line - len(match.Before)
+		for j, line := range match.Before {
+			fmt.Printf("%s-%d- %s\n", target, first+j, line)
+		}
+		fmt.Printf("%s:%d: %s\n", target, match.Line, match.Text)
+		for j, line := range match.After {
+			fmt.Printf("%s-%d- %s\n", target, match.Line+1+j, line)
+		}
+	}
+	return 0
+}
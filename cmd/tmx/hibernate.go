@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/DreamCats/tmuxmanager/internal/archive"
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// runHibernate 处理 tmx hibernate <会话...>
+// 保存会话的窗口、窗格、工作目录和滚动历史，然后关闭会话
+func runHibernate(settings *config.Settings, args []string) int {
+	if len(args) == 0 {
+		fmt.Println(i18n.T("hibernate.usage"))
+		return 1
+	}
+	dir, err := settings.ArchiveDir()
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	manager := tmux.NewManager()
+	sessions, err := manager.ListSessions()
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+
+	failed := 0
+	for _, name := range args {
+		var session *tmux.Session
+		for i := range sessions {
+			if sessions[i].Name == name {
+				session = &sessions[i]
+			}
+		}
+		if session == nil {
+			fmt.Println(i18n.T("hibernate.not_found", name))
+			failed++
+			continue
+		}
+		path, err := archive.SaveAndKill(manager, *session, dir, archive.ReasonHibernate)
+		if err != nil {
+			fmt.Println(i18n.T("cli.error", err))
+			failed++
+			continue
+		}
+		fmt.Println(i18n.T("hibernate.done", name, path))
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runRevive 处理 tmx revive [会话...]
+// 不指定会话时列出所有存档，否则按会话最近的存档重建会话
+func runRevive(settings *config.Settings, args []string) int {
+	dir, err := settings.ArchiveDir()
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	if len(args) == 0 {
+		return listArchives(dir)
+	}
+
+	manager := tmux.NewManager()
+	failed := 0
+	for _, name := range args {
+		s, err := archive.Find(dir, name)
+		if err == nil {
+			err = archive.Revive(manager, s)
+		}
+		if err != nil {
+			fmt.Println(i18n.T("cli.error", err))
+			failed++
+			continue
+		}
+		fmt.Println(i18n.T("revive.done", name, i18n.N("ls.windows", len(s.Windows))))
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// listArchives 列出存档目录中的会话，最近保存的在前
+func listArchives(dir string) int {
+	sessions, err := archive.List(dir)
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	if len(sessions) == 0 {
+		fmt.Println(i18n.T("revive.none", dir))
+		return 0
+	}
+	for _, s := range sessions {
+		fmt.Printf("%-20s %-12s %s  %s  %s\n", s.Name, reasonLabel(s.Reason),
+			s.Saved.Format("2006-01-02 15:04"), i18n.N("ls.windows", len(s.Windows)), s.Path)
+	}
+	return 0
+}
+
+// latestArchive 返回最近保存的存档，没有存档时 ok 为 false
+func latestArchive(settings *config.Settings) (_ archive.Session, ok bool) {
+	dir, err := settings.ArchiveDir()
+	if err != nil {
+		return archive.Session{}, false
+	}
+	sessions, err := archive.List(dir)
+	if err != nil || len(sessions) == 0 {
+		return archive.Session{}, false
+	}
+	return sessions[0], true
+}
+
+// reasonLabel 返回存档原因的显示名称
+func reasonLabel(reason string) string {
+	if reason == archive.ReasonReap {
+		return i18n.T("archive.reason.reap")
+	}
+	return i18n.T("archive.reason.hibernate")
+}
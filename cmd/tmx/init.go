@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/shell"
+)
+
+// runInit 处理 tmx init <shell> [--auto-attach] [--alias 名称|--no-alias]
+// 输出供 eval 的 shell 代码，集成随 tmx 升级而不是固化在 rc 文件中
+func runInit(settings *config.Settings, args []string) int {
+	if len(args) == 0 {
+		fmt.Println(i18n.T("init.usage"))
+		return 1
+	}
+
+	opts := shell.InitOptions{
+		AutoAttach: settings.Init.AutoAttach,
+		Alias:      settings.Init.Alias,
+	}
+	if opts.Alias == "" {
+		opts.Alias = "t"
+	}
+	for i := 1; i < len(args); i++ {
+		switch args[i] {
+		case "--auto-attach":
+			opts.AutoAttach = true
+		case "--no-alias":
+			opts.Alias = "none"
+		case "--alias":
+			if i+1 < len(args) {
+				opts.Alias = args[i+1]
+				i++
+			}
+		default:
+			fmt.Println(i18n.T("cli.unknown_arg", args[i]))
+			return 1
+		}
+	}
+	if opts.Alias == "none" {
+		opts.Alias = ""
+	}
+
+	script, err := shell.Init(args[0], opts)
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	fmt.Print(script)
+	return 0
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/git"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/proc"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// usageInterval 是 tmx ls 计算 CPU 占用的采样间隔
+const usageInterval = 500 * time.Millisecond
+
+// lsSession 是 tmx ls --format json 输出的会话
+type lsSession struct {
+	Name     string     `json:"name"`
+	Path     string     `json:"path"`
+	Created  time.Time  `json:"created"`
+	Attached bool       `json:"attached"`
+	CPU      float64    `json:"cpu"`
+	RSS      uint64     `json:"rss"`
+	Git      *lsGit     `json:"git,omitempty"` // 工作目录不是 git 仓库时省略
+	Windows  []lsWindow `json:"windows"`
+}
+
+type lsGit struct {
+	Branch  string `json:"branch"`
+	Ahead   int    `json:"ahead"`
+	Behind  int    `json:"behind"`
+	Changes int    `json:"changes"`
+}
+
+type lsWindow struct {
+	Index  int      `json:"index"`
+	Name   string   `json:"name"`
+	Active bool     `json:"active"`
+	CPU    float64  `json:"cpu"`
+	RSS    uint64   `json:"rss"`
+	Panes  []lsPane `json:"panes"`
+}
+
+type lsPane struct {
+	ID      string  `json:"id"`
+	Index   int     `json:"index"`
+	Command string  `json:"command"`
+	Path    string  `json:"path"`
+	PID     int     `json:"pid"`
+	CPU     float64 `json:"cpu"`
+	RSS     uint64  `json:"rss"`
+}
+
+// runLs 处理 tmx ls [--format text|json] [--sort cpu|mem] [--dirty]
+// 列出会话及其进程的 CPU 和内存占用、工作目录的 git 状态
+func runLs(args []string) int {
+	format := "text"
+	sortKey := proc.SortNone
+	dirtyOnly := false
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; arg {
+		case "--dirty":
+			dirtyOnly = true
+		case "--format", "--sort":
+			if i+1 >= len(args) {
+				fmt.Println(i18n.T("ls.usage"))
+				return 1
+			}
+			value := args[i+1]
+			i++
+			switch {
+			case arg == "--format" && (value == "text" || value == "json"):
+				format = value
+			case arg == "--sort" && value == "cpu":
+				sortKey = proc.SortCPU
+			case arg == "--sort" && value == "mem":
+				sortKey = proc.SortRSS
+			default:
+				fmt.Println(i18n.T("cli.unknown_arg", value))
+				return 1
+			}
+		default:
+			fmt.Println(i18n.T("cli.unknown_arg", arg))
+			return 1
+		}
+	}
+
+	manager := tmux.NewManager()
+	sessions, err := manager.ListSessions()
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	windows, err := manager.ListWindows("")
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	panes, err := manager.ListPanes("")
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	// 没有 /proc 时仍然列出会话，资源占用为 0
+	var usage proc.Resources
+	if cur, prev, err := proc.Sample(usageInterval); err == nil {
+		usage = proc.Collect(panes, cur, prev)
+	}
+	usage.SortSessions(sessions, sortKey)
+
+	var dirs []string
+	for _, s := range sessions {
+		dirs = append(dirs, s.Path)
+	}
+	statuses := git.NewCache(0).Statuses(dirs)
+	if dirtyOnly {
+		var dirty []tmux.Session
+		for _, s := range sessions {
+			if status, ok := statuses[s.Path]; ok && status.Dirty() {
+				dirty = append(dirty, s)
+			}
+		}
+		sessions = dirty
+	}
+
+	if format == "json" {
+		return printLsJSON(sessions, windows, panes, usage, statuses)
+	}
+	for _, s := range sessions {
+		u := usage.Sessions[s.Name]
+		attached := ""
+		if s.Attached {
+			attached = " " + i18n.T("ls.attached")
+		}
+		badge := ""
+		if status, ok := statuses[s.Path]; ok {
+			badge = "  [" + status.String() + "]"
+		}
+		fmt.Printf("%-20s %7s %6s  %s%s%s\n", s.Name, proc.FormatCPU(u.CPU), proc.FormatRSS(u.RSS),
+			i18n.N("ls.windows", s.Windows), attached, badge)
+	}
+	return 0
+}
+
+// printLsJSON 以 JSON 输出会话、窗口和窗格的资源占用及会话目录的 git 状态
+func printLsJSON(sessions []tmux.Session, windows []tmux.Window, panes []tmux.Pane, usage proc.Resources,
+	statuses map[string]git.Status) int {
+	out := make([]lsSession, 0, len(sessions))
+	for _, s := range sessions {
+		u := usage.Sessions[s.Name]
+		ls := lsSession{Name: s.Name, Path: s.Path, Created: s.Created, Attached: s.Attached, CPU: roundCPU(u.CPU),
+			RSS: u.RSS, Windows: []lsWindow{}}
+		if status, ok := statuses[s.Path]; ok {
+			ls.Git = &lsGit{Branch: status.Branch, Ahead: status.Ahead, Behind: status.Behind, Changes: status.Changes}
+		}
+		for _, w := range windows {
+			if w.Session != s.Name {
+				continue
+			}
+			u := usage.Windows[w.Target()]
+			lw := lsWindow{Index: w.Index, Name: w.Name, Active: w.Active, CPU: roundCPU(u.CPU), RSS: u.RSS,
+				Panes: []lsPane{}}
+			for _, p := range panes {
+				if p.Session != s.Name || p.Window != w.Index {
+					continue
+				}
+				u := usage.Panes[p.ID]
+				lw.Panes = append(lw.Panes, lsPane{ID: p.ID, Index: p.Index, Command: p.Command, Path: p.Path,
+					PID: p.PID, CPU: roundCPU(u.CPU), RSS: u.RSS})
+			}
+			ls.Windows = append(ls.Windows, lw)
+		}
+		out = append(out, ls)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	return 0
+}
+
+// roundCPU 保留一位小数
+func roundCPU(cpu float64) float64 {
+	return math.Round(cpu*10) / 10
+}
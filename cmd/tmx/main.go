@@ -3,15 +3,25 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
 
-	tea "github.com/charmbracelet/bubbletea"
+	"github.com/DreamCats/tmuxmanager/internal/archive"
 	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/record"
 	"github.com/DreamCats/tmuxmanager/internal/tmux"
 	"github.com/DreamCats/tmuxmanager/internal/ui"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
+const version = "1.0.0"
+
 func main() {
+	settings := loadSettings()
+
+	// 由 display-popup 启动时的参数
+	popup := false
+	client := ""
+
 	// 先检查命令行参数（不需要 tmux 运行）
 	if len(os.Args) > 1 {
 		switch os.Args[1] {
@@ -22,169 +32,193 @@ func main() {
 			printVersion()
 			os.Exit(0)
 		case "--install":
-			installConfig()
+			installConfig(settings, installOptions(os.Args[2:]))
 			os.Exit(0)
 		case "--uninstall":
-			uninstallConfig()
+			uninstallConfig(installOptions(os.Args[2:]))
 			os.Exit(0)
+		case "theme":
+			os.Exit(runTheme(settings, os.Args[2:]))
+		case "status":
+			os.Exit(runStatus(settings, os.Args[2:]))
+		case "doctor":
+			os.Exit(runDoctor())
+		case "init":
+			os.Exit(runInit(settings, os.Args[2:]))
+		case "ls":
+			os.Exit(runLs(os.Args[2:]))
+		case "ps":
+			os.Exit(runPs(os.Args[2:]))
+		case "hibernate":
+			os.Exit(runHibernate(settings, os.Args[2:]))
+		case "revive":
+			os.Exit(runRevive(settings, os.Args[2:]))
+		case "reap":
+			os.Exit(runReap(settings, os.Args[2:]))
+		case "attach":
+			os.Exit(runAttach(os.Args[2:]))
+		case "send":
+			os.Exit(runSend(os.Args[2:]))
+		case "grep":
+			os.Exit(runGrep(os.Args[2:]))
+		case "capture":
+			os.Exit(runCapture(settings, os.Args[2:]))
+		case "watch":
+			os.Exit(runWatch(os.Args[2:]))
+		case "__watch":
+			os.Exit(runWatcher(settings, os.Args[2:]))
+		case "notify":
+			os.Exit(runNotify(settings, os.Args[2:]))
+		case "record":
+			os.Exit(runRecord(settings, os.Args[2:]))
+		case "replay":
+			os.Exit(runReplay(settings, os.Args[2:]))
+		case "__record":
+			os.Exit(runRecorder(os.Args[2:]))
+		case "completion":
+			os.Exit(runCompletion(os.Args[2:]))
+		case "__complete":
+			os.Exit(runComplete(settings, os.Args[2:]))
+		case "--popup":
+			popup = true
+			if len(os.Args) > 3 && os.Args[2] == "--client" {
+				client = os.Args[3]
+			}
 		default:
-			fmt.Printf("未知参数: %s\n", os.Args[1])
-			fmt.Println("使用 -h 查看帮助")
+			fmt.Println(i18n.T("cli.unknown_arg", os.Args[1]))
+			fmt.Println(i18n.T("cli.see_help"))
 			os.Exit(1)
 		}
 	}
 
-	// 检查是否在 tmux 会话中
-	if os.Getenv("TMUX") == "" {
-		fmt.Println("📝 tmx 需要在 tmux 会话中运行")
-		fmt.Println("\n💡 使用方法：")
-		fmt.Println("   tmux                          # 启动 tmux")
-		fmt.Println("   tmx                           # 在 tmux 中运行管理器")
-		fmt.Println("\n或者：")
-		fmt.Println("   tmux attach-session -t default  # 连接到现有会话")
-		fmt.Println("   tmx                           # 然后运行 tmx")
-		fmt.Println("\n💡 提示：运行 ./tmx --install 可配置 Ctrl+b t 快捷键")
-		os.Exit(1)
-	}
-
-	// 检查 tmux 是否运行
+	// 检查 tmux 是否运行，在终端中直接运行 tmx 时可能还没有服务器
 	manager := tmux.NewManager()
 	if !manager.IsTmuxRunning() {
 		// tmux 未运行，询问是否自动启动
-		fmt.Println("📝 tmux 未运行")
-		fmt.Println("\n💡 tmx 可以自动启动 tmux 并创建默认会话")
-		fmt.Print("是否自动启动? [Y/n]: ")
+		fmt.Println(i18n.T("cli.tmux_not_running"))
+		fmt.Println(i18n.T("cli.auto_start_hint"))
+		// 有休眠或清理的会话时，也可以直接恢复最近保存的一个
+		latest, hasArchive := latestArchive(settings)
+		if hasArchive {
+			fmt.Println(i18n.T("cli.revive_hint", latest.Name,
+				latest.Saved.Format("2006-01-02 15:04"), i18n.N("ls.windows", len(latest.Windows))))
+			fmt.Print(i18n.T("cli.auto_start_revive_prompt"))
+		} else {
+			fmt.Print(i18n.T("cli.auto_start_prompt"))
+		}
 
 		var answer string
 		fmt.Scanln(&answer)
 
-		// 默认是 Y，或者用户输入 y/Y
-		if answer == "" || answer == "y" || answer == "Y" {
-			fmt.Println("\n🚀 正在启动 tmux...")
-
-			// 检查是否已有 default 会话
-			sessions, _ := manager.ListSessions()
-			hasDefault := false
-			for _, s := range sessions {
-				if s.Name == "default" {
-					hasDefault = true
-					break
-				}
+		if hasArchive && (answer == "r" || answer == "R") {
+			fmt.Println(i18n.T("cli.reviving", latest.Name))
+			if err := archive.Revive(manager, latest); err != nil {
+				fmt.Println(i18n.T("cli.error", err))
+				os.Exit(1)
 			}
-
-			if hasDefault {
-				// default 会话已存在，直接附加
-				fmt.Println("✓ 找到现有会话 'default'，正在连接...")
-				attachCmd := exec.Command("tmux", "attach-session", "-t", "default")
-				attachCmd.Stdin = os.Stdin
-				attachCmd.Stdout = os.Stdout
-				attachCmd.Stderr = os.Stderr
-
-				if err := attachCmd.Run(); err != nil {
-					fmt.Printf("❌ 附加到 tmux 会话失败: %v\n", err)
-					os.Exit(1)
-				}
-				return
-			} else {
-				// 创建新会话
-				createCmd := exec.Command("tmux", "new-session", "-d", "-s", "default")
-				if err := createCmd.Run(); err != nil {
-					fmt.Printf("❌ 创建 tmux 会话失败: %v\n", err)
-					fmt.Println("\n你可以手动启动 tmux：")
-					fmt.Println("  tmux")
-					os.Exit(1)
-				}
-
-				// 设置 tmux 在附加后运行 tmx
-				execCmd := exec.Command("tmux", "send-keys", "-t", "default", "tmx", "C-m")
-				if err := execCmd.Run(); err != nil {
-					fmt.Printf("⚠️  警告: 无法自动启动 tmx: %v\n", err)
-				}
-
-				// 附加到会话
-				attachCmd := exec.Command("tmux", "attach-session", "-t", "default")
-				attachCmd.Stdin = os.Stdin
-				attachCmd.Stdout = os.Stdout
-				attachCmd.Stderr = os.Stderr
-
-				if err := attachCmd.Run(); err != nil {
-					fmt.Printf("❌ 附加到 tmux 会话失败: %v\n", err)
-					os.Exit(1)
-				}
-				return
+			if err := manager.AttachSession(latest.Name); err != nil {
+				fmt.Println(i18n.T("cli.attach_error", err))
+				os.Exit(1)
 			}
-		} else {
-			// 用户选择不自动启动
-			fmt.Println("\n请先启动 tmux：")
-			fmt.Println("  tmux")
-			fmt.Println("\n或者创建新会话：")
-			fmt.Println("  tmux new")
+			return
+		}
+
+		// 默认是 Y，或者用户输入 y/Y
+		if answer != "" && answer != "y" && answer != "Y" {
+			fmt.Println(i18n.T("cli.start_tmux_first"))
+			os.Exit(1)
+		}
+
+		// 启动服务器并进入 default 会话，成功时 tmux 接管当前进程
+		fmt.Println(i18n.T("cli.starting_tmux"))
+		if err := manager.NewSessionAndAttach("default"); err != nil {
+			fmt.Println(i18n.T("cli.create_failed", err))
+			fmt.Println(i18n.T("cli.start_manually"))
 			os.Exit(1)
 		}
+		return
 	}
 
 	// 启动 TUI
-	model := ui.NewModel()
+	applyTheme(settings)
+	exportDir, _ := settings.ExportDir()
+	recordDir, _ := settings.RecordDir()
+	archiveDir, _ := settings.ArchiveDir()
+	out := ui.NewOutput(os.Stdout)
+	model := ui.NewModel().WithPopup(popup).WithExportDir(exportDir).WithOutput(out).
+		WithRecording(record.Options{Dir: recordDir, Cast: settings.Record.Cast}).
+		WithReaper(reapPolicy(settings, manager), archiveDir)
 	p := tea.NewProgram(
 		model,
+		tea.WithOutput(out),
 		tea.WithAltScreen(),       // 使用备用屏幕
 		tea.WithMouseCellMotion(), // 启用鼠标支持
 	)
 
 	finalModel, err := p.Run()
 	if err != nil {
-		fmt.Printf("错误: %v\n", err)
+		fmt.Println(i18n.T("cli.error", err))
 		os.Exit(1)
 	}
 
-	// 检查是否需要附加到会话
-	if m, ok := finalModel.(ui.Model); ok && m.AttachSessionName() != "" {
-		if err := manager.AttachSession(m.AttachSessionName()); err != nil {
-			fmt.Printf("错误: 无法连接到会话: %v\n", err)
-			os.Exit(1)
+	// 检查是否需要连接到会话
+	// 在 tmux 外运行时 Attach 以 tmux attach-session 替换当前进程
+	if m, ok := finalModel.(ui.Model); ok {
+		if target, opts := m.AttachTarget(); target != "" {
+			// 弹窗中切换指定客户端，tmx 退出后 display-popup -E 自动关闭弹窗
+			if popup {
+				opts.Client = client
+			}
+			if err := manager.Attach(target, opts); err != nil {
+				fmt.Println(i18n.T("cli.attach_error", err))
+				os.Exit(1)
+			}
 		}
 	}
 }
 
 func printHelp() {
-	fmt.Println("tmx - Tmux 会话管理器")
-	fmt.Println("\n用法:")
-	fmt.Println("  tmx                打开会话管理器（TUI）")
-	fmt.Println("  tmx --install      安装 tmux 配置（快捷键 + 状态栏提示）")
-	fmt.Println("  tmx --uninstall    卸载 tmux 配置")
-	fmt.Println("  tmx -h             显示帮助")
-	fmt.Println("  tmx -v             显示版本")
-	fmt.Println("\n注意: tmx 需要在 tmux 会话中运行")
-	fmt.Println("\n💡 使用方法：")
-	fmt.Println("   tmux              # 启动 tmux")
-	fmt.Println("   tmx               # 在 tmux 中运行管理器")
-	fmt.Println("   或运行 ./tmx --install 配置 Ctrl+b t 快捷键")
-	fmt.Println("\nTUI 快捷键:")
-	fmt.Println("  Enter           进入选中的会话")
-	fmt.Println("  n               新建会话")
-	fmt.Println("  d               断开会话")
-	fmt.Println("  x               删除会话")
-	fmt.Println("  ↑/↓ 或 j/k      导航")
-	fmt.Println("  q/Esc           退出")
-	fmt.Println("\n退出 tmux 会话:")
-	fmt.Println("  Ctrl+b d        分离会话（保持运行）")
-	fmt.Println("  quit            分离会话（需要先运行 ./tmx --install）")
+	fmt.Print(i18n.T("cli.help"))
 }
 
 func printVersion() {
-	fmt.Println("tmx version 1.0.0")
+	fmt.Println(i18n.T("cli.version", version))
+}
+
+// loadSettings 读取用户配置并初始化界面语言
+func loadSettings() *config.Settings {
+	settings, err := config.LoadSettings()
+	i18n.Init(settings.Language)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.settings_error", err))
+	}
+	return settings
+}
+
+// installOptions 解析 --install / --uninstall 之后的参数
+func installOptions(args []string) config.Options {
+	var opts config.Options
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run":
+			opts.DryRun = true
+		case "--all-shells":
+			opts.AllShells = true
+		}
+	}
+	return opts
 }
 
-func installConfig() {
-	if err := config.InstallConfig(); err != nil {
-		fmt.Printf("错误: %v\n", err)
+func installConfig(settings *config.Settings, opts config.Options) {
+	if err := config.InstallConfig(settings, opts); err != nil {
+		fmt.Println(i18n.T("cli.error", err))
 		os.Exit(1)
 	}
 }
 
-func uninstallConfig() {
-	if err := config.UninstallConfig(); err != nil {
-		fmt.Printf("错误: %v\n", err)
+func uninstallConfig(opts config.Options) {
+	if err := config.UninstallConfig(opts); err != nil {
+		fmt.Println(i18n.T("cli.error", err))
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/notify"
+)
+
+// runNotify 处理 tmx notify <bell|silence|activity> <会话> <窗口> [窗口名] 和 tmx notify test
+// 由 tmx --install 写入的 alert-* 钩子调用，只发送配置中关注的提醒
+func runNotify(settings *config.Settings, args []string) int {
+	var message string
+	switch {
+	case len(args) == 1 && args[0] == "test":
+		message = i18n.T("notify.test")
+	case len(args) >= 3:
+		event, target := args[0], args[1]+":"+args[2]
+		if !slices.Contains(settings.Notify.NotifyEvents(), event) {
+			return 0
+		}
+		name := ""
+		if len(args) > 3 {
+			name = args[3]
+		}
+		switch event {
+		case "bell":
+			message = i18n.T("notify.bell", target, name)
+		case "silence":
+			message = i18n.T("notify.silence", target, name)
+		case "activity":
+			message = i18n.T("notify.activity", target, name)
+		default:
+			fmt.Println(i18n.T("notify.usage"))
+			return 1
+		}
+	default:
+		fmt.Println(i18n.T("notify.usage"))
+		return 1
+	}
+
+	if err := notify.Send(settings.Notify.Command, "tmx", message); err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	return 0
+}
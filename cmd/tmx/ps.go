@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/proc"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/DreamCats/tmuxmanager/internal/watch"
+)
+
+// runPs 处理 tmx ps [--port 端口] [目标...]
+// 列出窗格中的进程树和监听的端口，--port 只列出监听该端口的进程
+func runPs(args []string) int {
+	var patterns []string
+	port := 0
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; arg {
+		case "--port":
+			if i+1 >= len(args) {
+				fmt.Println(i18n.T("ps.usage"))
+				return 1
+			}
+			n, err := strconv.Atoi(strings.TrimPrefix(args[i+1], ":"))
+			if err != nil || n <= 0 {
+				fmt.Println(i18n.T("cli.unknown_arg", args[i+1]))
+				return 1
+			}
+			port = n
+			i++
+		default:
+			if strings.HasPrefix(arg, "-") {
+				fmt.Println(i18n.T("cli.unknown_arg", arg))
+				return 1
+			}
+			patterns = append(patterns, arg)
+		}
+	}
+
+	manager := tmux.NewManager()
+	var panes []tmux.Pane
+	var err error
+	if len(patterns) == 0 {
+		panes, err = manager.ListPanes("")
+	} else {
+		panes, err = manager.ResolvePanes(patterns)
+	}
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	if len(panes) == 0 {
+		fmt.Println(i18n.T("send.no_targets", strings.Join(patterns, " ")))
+		return 1
+	}
+	trees, err := proc.Trees(panes)
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+
+	if port > 0 {
+		return printPortOwners(trees, port)
+	}
+	for i, t := range trees {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s  %s  %s\n", t.Pane.Target(), t.Pane.Command, t.Pane.Path)
+		for _, n := range t.Nodes {
+			fmt.Println(formatNode(n))
+		}
+	}
+	return 0
+}
+
+// printPortOwners 列出监听 port 的进程及其所在的窗格，没有时返回 1
+func printPortOwners(trees []proc.PaneTree, port int) int {
+	found := false
+	for _, t := range trees {
+		for _, n := range t.Nodes {
+			for _, p := range n.Ports {
+				if p.Port == port {
+					fmt.Printf("%-16s %7d  %-20s %s\n", t.Pane.Target(), n.PID, p, n.Cmdline)
+					found = true
+				}
+			}
+		}
+	}
+	if !found {
+		fmt.Println(i18n.T("ps.no_port", port))
+		return 1
+	}
+	return 0
+}
+
+// formatNode 返回进程树中的一行：PID、运行时长、按层级缩进的命令行和监听的端口
+func formatNode(n proc.Node) string {
+	indent := ""
+	if n.Depth > 0 {
+		indent = strings.Repeat("  ", n.Depth-1) + "└ "
+	}
+	line := fmt.Sprintf("%8d %7s  %s%s", n.PID, watch.FormatDuration(time.Since(n.Started)), indent, n.Cmdline)
+	for _, p := range n.Ports {
+		line += "  [" + p.String() + "]"
+	}
+	return line
+}
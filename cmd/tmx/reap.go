@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/DreamCats/tmuxmanager/internal/archive"
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/reap"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/DreamCats/tmuxmanager/internal/watch"
+)
+
+// reapPolicy 根据配置生成清理策略，运行 tmx 的当前会话不会被清理
+func reapPolicy(settings *config.Settings, manager *tmux.Manager) reap.Policy {
+	return reap.Policy{
+		Idle:    settings.Reap.Idle(),
+		Match:   settings.Reap.Match,
+		Pinned:  settings.Reap.Pinned,
+		Exclude: manager.CurrentSession(),
+	}
+}
+
+// runReap 处理 tmx reap [--dry-run]
+// 按配置的策略找出空闲会话，先保存布局和滚动历史再关闭
+func runReap(settings *config.Settings, args []string) int {
+	dryRun := false
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run", "-n":
+			dryRun = true
+		default:
+			fmt.Println(i18n.T("cli.unknown_arg", arg))
+			fmt.Println(i18n.T("reap.usage"))
+			return 1
+		}
+	}
+
+	dir, err := settings.ArchiveDir()
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	manager := tmux.NewManager()
+	sessions, err := manager.ListSessions()
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	candidates := reapPolicy(settings, manager).Candidates(sessions)
+	if len(candidates) == 0 {
+		fmt.Println(i18n.T("reap.none", watch.FormatDuration(settings.Reap.Idle())))
+		return 0
+	}
+
+	failed := 0
+	for _, c := range candidates {
+		if dryRun {
+			fmt.Printf("%-20s %s  %s  %s\n", c.Session.Name, i18n.T("reap.idle", watch.FormatDuration(c.Idle)),
+				i18n.N("ls.windows", c.Session.Windows), c.Session.Path)
+			continue
+		}
+		path, err := archive.SaveAndKill(manager, c.Session, dir, archive.ReasonReap)
+		if err != nil {
+			fmt.Println(i18n.T("cli.error", err))
+			failed++
+			continue
+		}
+		fmt.Println(i18n.T("reap.archived", c.Session.Name, path))
+	}
+	if dryRun {
+		fmt.Println(i18n.N("reap.dry_run", len(candidates)))
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
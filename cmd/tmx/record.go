@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/record"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/DreamCats/tmuxmanager/internal/ui"
+	"github.com/charmbracelet/bubbletea"
+)
+
+// runRecord 处理 tmx record [--stop] [--cast] <目标...>
+// 目标的形式与 tmx send 相同，录制其中的每个窗格
+func runRecord(settings *config.Settings, args []string) int {
+	var patterns []string
+	stop, cast := false, settings.Record.Cast
+	for _, arg := range args {
+		switch arg {
+		case "--stop":
+			stop = true
+		case "--cast":
+			cast = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				fmt.Println(i18n.T("cli.unknown_arg", arg))
+				return 1
+			}
+			patterns = append(patterns, arg)
+		}
+	}
+	if len(patterns) == 0 {
+		fmt.Println(i18n.T("record.usage"))
+		return 1
+	}
+
+	dir, err := settings.RecordDir()
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	manager := tmux.NewManager()
+	panes, err := manager.ResolvePanes(patterns)
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	if len(panes) == 0 {
+		fmt.Println(i18n.T("send.no_targets", strings.Join(patterns, " ")))
+		return 1
+	}
+
+	failed := 0
+	for _, p := range panes {
+		switch {
+		case stop && p.Recording != "":
+			err = record.Stop(manager, p)
+			if err == nil {
+				fmt.Println(i18n.T("record.stopped", p.Target(), p.Recording))
+			}
+		case stop:
+			continue
+		case p.Recording != "":
+			fmt.Println(i18n.T("record.already", p.Target(), p.Recording))
+			continue
+		default:
+			var path string
+			path, err = record.Start(manager, p, record.Options{Dir: dir, Cast: cast})
+			if err == nil {
+				fmt.Println(i18n.T("record.started", p.Target(), path))
+			}
+		}
+		if err != nil {
+			fmt.Println(i18n.T("cli.error", err))
+			failed++
+		}
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runRecorder 处理 tmx __record --log <文件> [--cast <文件> --size <宽x高>]
+// 由 pipe-pane 启动，从标准输入读取窗格输出直到录制停止
+func runRecorder(args []string) int {
+	logPath, castPath := "", ""
+	width, height := 80, 24
+	for i := 0; i+1 < len(args); i += 2 {
+		switch args[i] {
+		case "--log":
+			logPath = args[i+1]
+		case "--cast":
+			castPath = args[i+1]
+		case "--size":
+			fmt.Sscanf(args[i+1], "%dx%d", &width, &height)
+		}
+	}
+	if logPath == "" {
+		return 1
+	}
+
+	recorder, err := record.NewRecorder(logPath, castPath, width, height)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := recorder.Run(os.Stdin); err != nil {
+		return 1
+	}
+	return 0
+}
+
+// runReplay 处理 tmx replay <文件>，在终端中回放录制的输出
+func runReplay(settings *config.Settings, args []string) int {
+	if len(args) != 1 {
+		fmt.Println(i18n.T("replay.usage"))
+		return 1
+	}
+	entries, err := record.Load(args[0])
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	if len(entries) == 0 {
+		fmt.Println(i18n.T("replay.empty", args[0]))
+		return 1
+	}
+
+	applyTheme(settings)
+	p := tea.NewProgram(ui.NewReplay(args[0], entries), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	return 0
+}
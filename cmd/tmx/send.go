@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// runSend 处理 tmx send <目标...> [-k] [-y] -- <命令>
+// 目标可以是窗格、窗口、会话或会话通配符，发送前列出目标并确认
+func runSend(args []string) int {
+	var patterns []string
+	keys, yes := false, false
+	i := 0
+	for ; i < len(args) && args[i] != "--"; i++ {
+		switch args[i] {
+		case "-k", "--keys":
+			keys = true
+		case "-y", "--yes":
+			yes = true
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				fmt.Println(i18n.T("cli.unknown_arg", args[i]))
+				return 1
+			}
+			patterns = append(patterns, args[i])
+		}
+	}
+	if i >= len(args)-1 || len(patterns) == 0 {
+		fmt.Println(i18n.T("send.usage"))
+		return 1
+	}
+	words := args[i+1:]
+
+	manager := tmux.NewManager()
+	panes, err := manager.ResolvePanes(patterns)
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	if len(panes) == 0 {
+		fmt.Println(i18n.T("send.no_targets", strings.Join(patterns, " ")))
+		return 1
+	}
+
+	// 预览目标
+	fmt.Println(i18n.N("send.preview", len(panes), strings.Join(words, " ")))
+	for _, p := range panes {
+		fmt.Printf("  %-20s %s\n", p.Target(), p.Command)
+	}
+	if !yes {
+		fmt.Print(i18n.T("send.confirm"))
+		var answer string
+		fmt.Scanln(&answer)
+		if answer != "y" && answer != "Y" {
+			return 1
+		}
+	}
+
+	failed := 0
+	for _, p := range panes {
+		if keys {
+			err = manager.SendKeys(p.Target(), words...)
+		} else {
+			err = manager.SendCommand(p.Target(), strings.Join(words, " "))
+		}
+		if err != nil {
+			fmt.Println(i18n.T("send.failed", err))
+			failed++
+		}
+	}
+	fmt.Println(i18n.N("send.sent", len(panes)-failed))
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/DreamCats/tmuxmanager/internal/watch"
+)
+
+// recentFinish 是状态栏显示命令结束信息的时长
+const recentFinish = 10 * time.Minute
+
+// runStatus 处理 tmx status [会话名]，输出可嵌入 status-left/status-right 的片段
+// 例如：set -ga status-right ' #(tmx status "#{session_name}")'
+func runStatus(settings *config.Settings, args []string) int {
+	manager := tmux.NewManager()
+	sessions, err := manager.ListSessions()
+	if err != nil {
+		// 状态栏中不输出错误信息，避免撑乱布局
+		return 1
+	}
+
+	current := ""
+	if len(args) > 0 {
+		current = args[0]
+	} else {
+		current = manager.CurrentSession()
+	}
+
+	segment := statusSegment(sessions, current)
+	if slices.Contains(settings.Watch.WatchAlerts(), "status") {
+		if panes, err := manager.ListPanes(""); err == nil {
+			if p, r, ok := watch.Recent(panes, recentFinish); ok {
+				segment += " · " + p.Target() + " " + finishedSummary(r)
+			}
+		}
+	}
+	fmt.Print(segment)
+	return 0
+}
+
+// statusSegment 生成状态栏片段：当前会话序号/会话总数 · 未连接会话数
+func statusSegment(sessions []tmux.Session, current string) string {
+	rank := 0
+	unattached := 0
+	for i, s := range sessions {
+		if s.Name == current {
+			rank = i + 1
+		}
+		if !s.Attached {
+			unattached++
+		}
+	}
+
+	segment := i18n.N("status.sessions", len(sessions))
+	if rank > 0 {
+		segment = i18n.T("status.rank", rank, len(sessions))
+	}
+	if unattached > 0 {
+		segment += " · " + i18n.N("status.unattached", unattached)
+	}
+	return segment
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/ui"
+)
+
+// applyTheme 根据配置设置 TUI 配色，配置无效时回退到自动选择
+func applyTheme(settings *config.Settings) {
+	theme, err := ui.ResolveTheme(settings)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("theme.invalid", err))
+		theme, _ = ui.ResolveTheme(&config.Settings{})
+	}
+	ui.ApplyTheme(theme)
+}
+
+// runTheme 处理 tmx theme 子命令，返回退出码
+func runTheme(settings *config.Settings, args []string) int {
+	if len(args) == 0 || args[0] != "preview" {
+		fmt.Println(i18n.T("theme.usage"))
+		return 1
+	}
+
+	names := ui.ThemeNames(settings.Themes)
+	if len(args) > 1 {
+		names = args[1:]
+	}
+
+	for _, name := range names {
+		theme, err := ui.LookupTheme(name, settings.Themes)
+		if err != nil {
+			fmt.Println(i18n.T("cli.error", err))
+			return 1
+		}
+		fmt.Println(ui.PreviewTheme(theme))
+	}
+
+	current, err := ui.ResolveTheme(settings)
+	if err == nil {
+		fmt.Println(i18n.T("theme.current", current.Name))
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/notify"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/DreamCats/tmuxmanager/internal/watch"
+)
+
+// runWatch 处理 tmx watch [--stop] [目标...]
+// 不带目标时列出正在等待和已经结束的窗格
+func runWatch(args []string) int {
+	var patterns []string
+	stop := false
+	for _, arg := range args {
+		switch arg {
+		case "--stop":
+			stop = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				fmt.Println(i18n.T("cli.unknown_arg", arg))
+				return 1
+			}
+			patterns = append(patterns, arg)
+		}
+	}
+
+	manager := tmux.NewManager()
+	if len(patterns) == 0 {
+		if stop {
+			fmt.Println(i18n.T("watch.usage"))
+			return 1
+		}
+		return listWatches(manager)
+	}
+
+	panes, err := manager.ResolvePanes(patterns)
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	if len(panes) == 0 {
+		fmt.Println(i18n.T("send.no_targets", strings.Join(patterns, " ")))
+		return 1
+	}
+
+	failed := 0
+	for _, p := range panes {
+		if stop {
+			err = watch.Stop(manager, p)
+		} else if !p.Watching {
+			err = watch.Start(manager, p)
+		}
+		if err != nil {
+			fmt.Println(i18n.T("cli.error", err))
+			failed++
+			continue
+		}
+		if stop {
+			fmt.Println(i18n.T("watch.stopped", p.Target()))
+		} else {
+			fmt.Println(i18n.T("watch.started", p.Target(), p.Command))
+		}
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// listWatches 列出正在等待和已经结束的窗格
+func listWatches(manager *tmux.Manager) int {
+	panes, err := manager.ListPanes("")
+	if err != nil {
+		fmt.Println(i18n.T("cli.error", err))
+		return 1
+	}
+	for _, p := range panes {
+		if p.Watching {
+			fmt.Printf("%-20s %s\n", p.Target(), i18n.T("watch.watching", p.Command))
+		} else if r, ok := watch.ParseResult(p.Finished); ok {
+			fmt.Printf("%-20s %s  %s\n", p.Target(), finishedSummary(r), r.Ended.Format("15:04:05"))
+		}
+	}
+	return 0
+}
+
+// finishedSummary 返回命令结束的简短描述，例如 ✓ make 3m12s
+func finishedSummary(r watch.Result) string {
+	if r.Status > 0 {
+		return i18n.T("watch.failed", r.Command, r.Status, watch.FormatDuration(r.Duration))
+	}
+	return i18n.T("watch.finished", r.Command, watch.FormatDuration(r.Duration))
+}
+
+// runWatcher 处理 tmx __watch <窗格 ID>，由 tmx watch 通过 run-shell -b 在后台启动
+func runWatcher(settings *config.Settings, args []string) int {
+	if len(args) != 1 {
+		return 1
+	}
+	err := watch.Run(tmux.NewManager(), args[0], func(p tmux.Pane, r watch.Result) {
+		alerts := settings.Watch.WatchAlerts()
+		if slices.Contains(alerts, "notify") {
+			notify.Send(settings.Notify.Command, "tmx", p.Target()+" "+finishedSummary(r))
+		}
+		if settings.Watch.Hook != "" {
+			runWatchHook(settings.Watch.Hook, p, r)
+		}
+	})
+	if err != nil {
+		return 1
+	}
+	return 0
+}
+
+// runWatchHook 执行用户配置的命令，结束信息通过环境变量传递
+func runWatchHook(hook string, p tmux.Pane, r watch.Result) {
+	status := ""
+	if r.Status >= 0 {
+		status = strconv.Itoa(r.Status)
+	}
+	cmd := exec.Command("sh", "-c", hook)
+	cmd.Env = append(os.Environ(),
+		"TMX_TARGET="+p.Target(),
+		"TMX_COMMAND="+r.Command,
+		"TMX_STATUS="+status,
+		"TMX_DURATION="+strconv.Itoa(int(r.Duration.Seconds())),
+	)
+	cmd.Run()
+}
@@ -0,0 +1,204 @@
+// Package archive 将会话的布局、工作目录、命令和滚动历史保存到磁盘，用于之后恢复
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// 保存会话的原因
+const (
+	ReasonReap      = "reap"      // 空闲过久，由 tmx reap 清理
+	ReasonHibernate = "hibernate" // 用户主动休眠
+)
+
+// manifest 是存档目录中描述会话的文件
+const manifest = "session.json"
+
+// Session 是保存到磁盘的会话
+type Session struct {
+	Name    string    `json:"name"`
+	Path    string    `json:"path"`
+	Reason  string    `json:"reason"`
+	Saved   time.Time `json:"saved"`
+	Windows []Window  `json:"windows"`
+
+	Dir string `json:"-"` // 存档所在的目录
+}
+
+// Window 是存档中的窗口
+type Window struct {
+	Index  int    `json:"index"`
+	Name   string `json:"name"`
+	Layout string `json:"layout"`
+	Active bool   `json:"active"`
+	Panes  []Pane `json:"panes"`
+	// Width 和 Height 是保存时窗口的大小，布局只能应用到同样大小的窗口
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+}
+
+// Pane 是存档中的窗格
+type Pane struct {
+	Index      int    `json:"index"`
+	Path       string `json:"path"`
+	Command    string `json:"command"`
+	Active     bool   `json:"active"`
+	Scrollback string `json:"scrollback"` // 滚动历史文件名，相对存档目录
+}
+
+// Save 将会话保存到 dir 下的新目录，返回存档目录
+// 目录名由会话名和时间组成，例如 dev-20250101-150405，重名时加上序号
+// 保存失败时删除不完整的存档目录
+func Save(m *tmux.Manager, session tmux.Session, dir, reason string) (_ string, err error) {
+	if dir == "" {
+		return "", fmt.Errorf("archive directory is not set")
+	}
+	windows, err := m.ListWindows(session.Name)
+	if err != nil {
+		return "", err
+	}
+	panes, err := m.ListPanes(session.Name)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	name := strings.NewReplacer("/", "_", ":", "_").Replace(session.Name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	// 同一秒内保存同名会话时加上序号，不能复用（以及在失败时删除）已有的存档
+	base := filepath.Join(dir, fmt.Sprintf("%s-%s", name, now.Format("20060102-150405")))
+	path := base
+	for i := 2; ; i++ {
+		err := os.Mkdir(path, 0o755)
+		if os.IsExist(err) {
+			path = fmt.Sprintf("%s-%d", base, i)
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		break
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(path)
+		}
+	}()
+
+	s := Session{Name: session.Name, Path: session.Path, Reason: reason, Saved: now}
+	for _, w := range windows {
+		window := Window{Index: w.Index, Name: w.Name, Layout: w.Layout, Active: w.Active, Width: w.Width, Height: w.Height}
+		for _, p := range panes {
+			if p.Window != w.Index {
+				continue
+			}
+			// 保留颜色，恢复时原样输出到新窗格
+			content, err := m.CaptureScrollback(p.Target(), true)
+			if err != nil {
+				return "", err
+			}
+			// 去掉屏幕下方的空行，恢复时新窗格的提示符紧接在历史之后
+			content = strings.TrimRight(content, "\n") + "\n"
+			file := fmt.Sprintf("%d.%d.log", p.Window, p.Index)
+			if err := os.WriteFile(filepath.Join(path, file), []byte(content), 0o644); err != nil {
+				return "", fmt.Errorf("failed to write %s: %w", file, err)
+			}
+			window.Panes = append(window.Panes, Pane{
+				Index: p.Index, Path: p.Path, Command: p.Command, Active: p.Active, Scrollback: file,
+			})
+		}
+		s.Windows = append(s.Windows, window)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(path, manifest), data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", manifest, err)
+	}
+	return path, nil
+}
+
+// SaveAndKill 保存会话后关闭会话，返回存档目录；保存失败时不关闭会话
+func SaveAndKill(m *tmux.Manager, session tmux.Session, dir, reason string) (string, error) {
+	path, err := Save(m, session, dir, reason)
+	if err != nil {
+		return "", err
+	}
+	if err := m.KillSession(session.Name); err != nil {
+		return path, fmt.Errorf("failed to kill %s: %w", session.Name, err)
+	}
+	return path, nil
+}
+
+// Load 读取存档目录中的会话
+func Load(path string) (Session, error) {
+	var s Session
+	data, err := os.ReadFile(filepath.Join(path, manifest))
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("failed to parse %s: %w", filepath.Join(path, manifest), err)
+	}
+	s.Dir = path
+	return s, nil
+}
+
+// List 返回 dir 下的所有存档，最近保存的在前；目录不存在时返回空列表
+// 无法读取的目录会被跳过
+func List(dir string) ([]Session, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var sessions []Session
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if s, err := Load(filepath.Join(dir, e.Name())); err == nil {
+			sessions = append(sessions, s)
+		}
+	}
+	slices.SortStableFunc(sessions, func(a, b Session) int {
+		return b.Saved.Compare(a.Saved)
+	})
+	return sessions, nil
+}
+
+// Find 返回会话 name 最近的一份存档
+func Find(dir, name string) (Session, error) {
+	sessions, err := List(dir)
+	if err != nil {
+		return Session{}, err
+	}
+	for _, s := range sessions {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return Session{}, fmt.Errorf("no archive of session %s in %s", name, dir)
+}
+
+// Remove 删除存档目录
+func Remove(s Session) error {
+	if s.Dir == "" {
+		return fmt.Errorf("archive of %s has no directory", s.Name)
+	}
+	return os.RemoveAll(s.Dir)
+}
@@ -0,0 +1,136 @@
+package archive
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/DreamCats/tmuxmanager/internal/watch"
+)
+
+// Revive 按存档重建会话：窗口索引、窗格、工作目录和布局与保存时一致，
+// 每个窗格先输出原来的滚动历史，再启动 shell。成功后删除存档
+// 已有同名会话时返回错误；重建失败时关闭不完整的会话并保留存档
+func Revive(m *tmux.Manager, s Session) error {
+	sessions, err := m.ListSessions()
+	if err != nil {
+		return err
+	}
+	for _, existing := range sessions {
+		if existing.Name == s.Name {
+			return fmt.Errorf("session %s already exists", s.Name)
+		}
+	}
+
+	// 窗格异步输出滚动历史，先把所有窗格的历史复制到临时目录，
+	// 由窗格输出后自行删除，这样存档可以立即删除
+	tmp, err := os.MkdirTemp("", "tmx-revive-")
+	if err != nil {
+		return err
+	}
+	if err := copyScrollback(s, tmp); err != nil {
+		os.RemoveAll(tmp)
+		return err
+	}
+	if err := rebuild(m, s, tmp); err != nil {
+		m.KillSession(s.Name)
+		os.RemoveAll(tmp)
+		return err
+	}
+	return Remove(s)
+}
+
+func rebuild(m *tmux.Manager, s Session, tmp string) error {
+	// 会话中的窗口使用同一个大小，取最大的窗口，保证每个窗口都能容纳保存时的窗格
+	width, height := 0, 0
+	for _, w := range s.Windows {
+		width, height = max(width, w.Width), max(height, w.Height)
+	}
+
+	created, activeWindow := false, ""
+	for _, w := range s.Windows {
+		var panes []string
+		for _, p := range w.Panes {
+			command := paneCommand(s, p, tmp)
+			var id string
+			var err error
+			switch {
+			case !created:
+				id, err = m.CreateSession(s.Name, w.Name, p.Path, command, width, height)
+				created = true
+				if err == nil {
+					// 第一个窗口的索引由 base-index 决定，移到保存时的索引
+					err = m.MoveWindowIndex(id, w.Index)
+				}
+			case len(panes) == 0:
+				id, err = m.CreateWindow(s.Name, w.Index, w.Name, p.Path, command)
+			default:
+				id, err = m.CreatePane(panes[len(panes)-1], p.Path, command)
+				if err == nil {
+					// 每次拆分后平铺，窗格较多时最后拆分的窗格也有空间继续拆分
+					err = m.SelectLayout(id, "tiled")
+				}
+			}
+			if err != nil {
+				return err
+			}
+			panes = append(panes, id)
+		}
+		if len(panes) == 0 {
+			continue
+		}
+
+		// 新窗格按创建顺序排列，与布局中窗格的顺序一致
+		if w.Layout != "" && len(panes) > 1 {
+			if err := m.SelectLayout(panes[0], w.Layout); err != nil {
+				return err
+			}
+		}
+		for i, p := range w.Panes {
+			if p.Active {
+				m.SelectPane(panes[i])
+			}
+		}
+		if w.Active {
+			activeWindow = panes[0]
+		}
+	}
+	if !created {
+		return fmt.Errorf("archive of %s has no panes", s.Name)
+	}
+	if activeWindow != "" {
+		m.SelectWindow(activeWindow)
+	}
+	return nil
+}
+
+// copyScrollback 将存档中每个窗格的滚动历史复制到 tmp
+func copyScrollback(s Session, tmp string) error {
+	for _, w := range s.Windows {
+		for _, p := range w.Panes {
+			data, err := os.ReadFile(filepath.Join(s.Dir, p.Scrollback))
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(tmp, p.Scrollback), data, 0o600); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// paneCommand 返回恢复窗格时运行的命令：输出滚动历史和一行提示，然后启动 shell
+// 最后一个输出完的窗格删除临时目录
+func paneCommand(s Session, p Pane, tmp string) string {
+	saved := s.Saved.Format("2006-01-02 15:04")
+	banner := i18n.T("archive.revived", saved)
+	if p.Command != "" && !watch.IsShell(p.Command) {
+		banner = i18n.T("archive.revived_command", saved, p.Command)
+	}
+	file := filepath.Join(tmp, p.Scrollback)
+	return fmt.Sprintf(`cat %s; rm -f %[1]s; rmdir %s 2>/dev/null; printf '\033[2m%%s\033[0m\n' %s; exec "${SHELL:-/bin/sh}"`,
+		tmux.ShellQuote(file), tmux.ShellQuote(tmp), tmux.ShellQuote(banner))
+}
@@ -0,0 +1,179 @@
+package archive
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// testServer 让 tmux 命令使用临时目录中的 socket，避免读写用户正在运行的 tmux
+func testServer(t *testing.T) *tmux.Manager {
+	t.Helper()
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	t.Setenv("TMUX", "")
+	t.Setenv("TMUX_TMPDIR", t.TempDir())
+	t.Cleanup(func() { exec.Command("tmux", "kill-server").Run() })
+	return tmux.NewManager()
+}
+
+func tmuxRun(t *testing.T, args ...string) {
+	t.Helper()
+	if output, err := exec.Command("tmux", args...).CombinedOutput(); err != nil {
+		t.Fatalf("tmux %s: %v: %s", strings.Join(args, " "), err, output)
+	}
+}
+
+func findSession(t *testing.T, m *tmux.Manager, name string) tmux.Session {
+	t.Helper()
+	sessions, err := m.ListSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range sessions {
+		if s.Name == name {
+			return s
+		}
+	}
+	t.Fatalf("session %s not found", name)
+	return tmux.Session{}
+}
+
+func TestReviveManyPanes(t *testing.T) {
+	m := testServer(t)
+	dir := t.TempDir()
+
+	// 大窗口中的 6 个窗格，在默认 80x24 的后台会话中逐个拆分会因空间不足失败
+	tmuxRun(t, "new-session", "-d", "-s", "big", "-x", "200", "-y", "50")
+	for i := 0; i < 5; i++ {
+		tmuxRun(t, "split-window", "-t", "big:", "-h")
+		tmuxRun(t, "select-layout", "-t", "big:", "even-horizontal")
+	}
+	tmuxRun(t, "new-window", "-t", "big:", "-n", "second")
+	windows, err := m.ListWindows("big")
+	if err != nil {
+		t.Fatal(err)
+	}
+	layout := windows[0].Layout
+
+	path, err := SaveAndKill(m, findSession(t, m, "big"), dir, ReasonHibernate)
+	if err != nil {
+		t.Fatalf("SaveAndKill: %v", err)
+	}
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := s.Windows[0]; len(w.Panes) != 6 || w.Width != 200 || w.Height != 50 {
+		t.Fatalf("saved window = %d panes %dx%d, want 6 panes 200x50", len(w.Panes), w.Width, w.Height)
+	}
+
+	if err := Revive(m, s); err != nil {
+		t.Fatalf("Revive: %v", err)
+	}
+	windows, err = m.ListWindows("big")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(windows) != 2 || windows[0].Panes != 6 || windows[1].Name != "second" {
+		t.Fatalf("revived windows = %+v", windows)
+	}
+	// 布局的校验和包含窗格 ID，只比较去掉校验和后的几何信息
+	if got, want := stripIDs(windows[0].Layout), stripIDs(layout); got != want {
+		t.Errorf("layout = %s, want %s", got, want)
+	}
+	if sessions, _ := List(dir); len(sessions) != 0 {
+		t.Errorf("archive not removed after revive: %v", sessions)
+	}
+}
+
+func TestReviveKeepsWindowIndexes(t *testing.T) {
+	m := testServer(t)
+	dir := t.TempDir()
+
+	// 窗口索引不连续，且第一个窗口不在 base-index
+	tmuxRun(t, "new-session", "-d", "-s", "gaps", "-n", "one")
+	tmuxRun(t, "move-window", "-s", "gaps:0", "-t", "gaps:1")
+	tmuxRun(t, "new-window", "-d", "-t", "gaps:3", "-n", "three")
+	tmuxRun(t, "new-window", "-d", "-t", "gaps:7", "-n", "seven")
+
+	s, err := Load(mustSave(t, m, findSession(t, m, "gaps"), dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmuxRun(t, "kill-session", "-t", "gaps")
+	if err := Revive(m, s); err != nil {
+		t.Fatalf("Revive: %v", err)
+	}
+
+	windows, err := m.ListWindows("gaps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, w := range windows {
+		got = append(got, w.Target()+" "+w.Name)
+	}
+	want := []string{"gaps:1 one", "gaps:3 three", "gaps:7 seven"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("revived windows = %v, want %v", got, want)
+	}
+}
+
+func TestReviveExistingSession(t *testing.T) {
+	m := testServer(t)
+	dir := t.TempDir()
+
+	tmuxRun(t, "new-session", "-d", "-s", "dup")
+	s, err := Load(mustSave(t, m, findSession(t, m, "dup"), dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Revive(m, s); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("Revive over an existing session: err = %v", err)
+	}
+	if sessions, _ := List(dir); len(sessions) != 1 {
+		t.Errorf("archive should be kept when revive fails, got %d", len(sessions))
+	}
+}
+
+func TestSaveKeepsEarlierArchives(t *testing.T) {
+	m := testServer(t)
+	dir := t.TempDir()
+
+	tmuxRun(t, "new-session", "-d", "-s", "dev")
+	// 同一秒内的存档目录名相同，不能复用前一个存档的目录
+	seen := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		path := mustSave(t, m, findSession(t, m, "dev"), dir)
+		if seen[path] {
+			t.Fatalf("save %d reused %s", i+1, path)
+		}
+		seen[path] = true
+	}
+	if sessions, _ := List(dir); len(sessions) != 3 {
+		t.Errorf("got %d archives, want 3", len(sessions))
+	}
+}
+
+func mustSave(t *testing.T, m *tmux.Manager, s tmux.Session, dir string) string {
+	t.Helper()
+	path, err := Save(m, s, dir, ReasonHibernate)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return path
+}
+
+// paneID 匹配布局中的窗格：大小、位置和窗格 ID
+var paneID = regexp.MustCompile(`(\d+x\d+,\d+,\d+),\d+`)
+
+// stripIDs 去掉布局的校验和和窗格 ID，例如 "ab12,80x24,0,0,3" 变为 "80x24,0,0"
+func stripIDs(layout string) string {
+	_, rest, _ := strings.Cut(layout, ",")
+	return paneID.ReplaceAllString(rest, "$1")
+}
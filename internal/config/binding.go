@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// 弹窗默认尺寸与边框
+const (
+	defaultPopupWidth  = "80%"
+	defaultPopupHeight = "80%"
+	defaultPopupBorder = "rounded"
+)
+
+// bindingCommand 返回 Ctrl+b t 绑定执行的 tmux 命令
+// tmux >= 3.2 使用 display-popup，更早的版本回退到 new-window
+// run-shell 没有可用的 TTY，无法运行全屏 TUI
+func bindingCommand(version tmux.Version, popup PopupConfig) string {
+	mode := popup.Mode
+	if mode == "" || mode == "auto" {
+		mode = "popup"
+	}
+	if mode == "popup" && !version.AtLeast(3, 2) {
+		mode = "window"
+	}
+
+	if mode == "window" {
+		return `new-window -n tmx "tmx"`
+	}
+
+	width := valueOr(popup.Width, defaultPopupWidth)
+	height := valueOr(popup.Height, defaultPopupHeight)
+	cmd := fmt.Sprintf("display-popup -E -w %s -h %s", width, height)
+	// -b 边框选项从 tmux 3.3 开始支持
+	if version.AtLeast(3, 3) {
+		cmd += " -b " + valueOr(popup.Border, defaultPopupBorder)
+	}
+	// #{client_name} 让 tmx 知道要切换哪个客户端
+	return cmd + ` "tmx --popup --client '#{client_name}'"`
+}
+
+// detectTmuxVersion 获取 tmux 版本，失败时按最低版本处理
+func detectTmuxVersion() tmux.Version {
+	version, err := tmux.NewManager().Version()
+	if err != nil {
+		return tmux.Version{}
+	}
+	return version
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// notifyHookIndex 是 tmx 使用的钩子数组下标，不会覆盖用户自己设置的钩子（默认下标 0）
+const notifyHookIndex = 90
+
+// notifyHooks 返回窗口提醒时调用 tmx notify 的 tmux 钩子
+// 钩子数组需要 tmux >= 3.0，更早的版本或未启用通知时返回空
+func notifyHooks(notify NotifyConfig, version tmux.Version) string {
+	if !notify.Enabled || !version.AtLeast(3, 0) {
+		return ""
+	}
+	lines := []string{"# " + notifyComment}
+	for _, event := range notify.NotifyEvents() {
+		lines = append(lines, fmt.Sprintf(
+			`set-hook -g alert-%s[%d] 'run-shell -b "tmx notify %s #{q:session_name} #{window_index} #{q:window_name}"'`,
+			event, notifyHookIndex, event))
+	}
+	return strings.Join(lines, "\n")
+}
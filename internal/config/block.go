@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+)
+
+// blockVersion 是当前 tmx 写入的配置块版本
+// 旧版本（不带版本号的 "========== tmx 配置 ==========" 标记）视为版本 1
+const blockVersion = 4
+
+// 配置块起止标记，例如：
+//
+//	# >>> tmx:tmux v4 >>>
+//	...
+//	# <<< tmx:tmux <<<
+var (
+	blockBeginPattern = regexp.MustCompile(`^# >>> tmx:(\S+) v(\d+) >>>$`)
+	blockEndPattern   = regexp.MustCompile(`^# <<< tmx:(\S+) <<<$`)
+)
+
+// legacyMarkers 是版本 1 配置块的起止标记，按块 ID 索引
+var legacyMarkers = map[string][2]string{
+	tmuxBlockID:  {"# ========== tmx 配置 ==========", "# ========== tmx 配置结束 =========="},
+	shellBlockID: {"# ========== tmx quit 命令 ==========", "# ========== tmx quit 命令结束 =========="},
+}
+
+// blockAliases 记录配置块改名前的 ID，旧块会被原地升级
+// shell 集成在版本 4 之前是直接写入 rc 文件的 quit 函数
+var blockAliases = map[string]string{
+	shellBlockID: "quit",
+}
+
+// matchesID 检查标记中的 ID 是否属于指定配置块（包括旧 ID）
+func matchesID(markerID, id string) bool {
+	return markerID == id || (blockAliases[id] != "" && markerID == blockAliases[id])
+}
+
+// Block 是 tmx 管理的一段配置
+type Block struct {
+	ID      string
+	Version int
+	Body    string
+}
+
+// BlockAction 描述写入配置块的结果
+type BlockAction int
+
+const (
+	BlockUnchanged BlockAction = iota // 已是最新，无需修改
+	BlockAdded                        // 新增配置块
+	BlockUpgraded                     // 原地替换了旧的配置块
+	BlockNewer                        // 文件中的配置块来自更新版本的 tmx，未修改
+	BlockBroken                       // 文件中的配置块缺少结束标记，未修改
+)
+
+// Render 渲染带起止标记的配置块，以换行结尾
+func (b Block) Render() string {
+	return fmt.Sprintf("# >>> tmx:%s v%d >>>\n%s\n# <<< tmx:%s <<<\n",
+		b.ID, b.Version, strings.TrimRight(b.Body, "\n"), b.ID)
+}
+
+// blockSpan 是配置块在文件中的位置（按行，end 不包含）
+type blockSpan struct {
+	start, end int
+	version    int
+	broken     bool   // 缺少结束标记，此时 end 只包含开始标记这一行
+	endMarker  string // 应有的结束标记
+}
+
+// findBlock 在行列表中查找指定 ID 的配置块，同时识别旧版本标记
+func findBlock(lines []string, id string) (blockSpan, bool) {
+	legacy, hasLegacy := legacyMarkers[id]
+	for i, line := range lines {
+		version := 0
+		if m := blockBeginPattern.FindStringSubmatch(line); m != nil && matchesID(m[1], id) {
+			version, _ = strconv.Atoi(m[2])
+		} else if hasLegacy && line == legacy[0] {
+			version = 1
+		} else {
+			continue
+		}
+
+		endMarker := legacy[1]
+		if m := blockBeginPattern.FindStringSubmatch(line); m != nil {
+			endMarker = fmt.Sprintf("# <<< tmx:%s <<<", m[1])
+		}
+		for j := i + 1; j < len(lines); j++ {
+			if m := blockEndPattern.FindStringSubmatch(lines[j]); m != nil && matchesID(m[1], id) {
+				return blockSpan{i, j + 1, version, false, endMarker}, true
+			}
+			if hasLegacy && version == 1 && lines[j] == legacy[1] {
+				return blockSpan{i, j + 1, version, false, endMarker}, true
+			}
+		}
+		// 没有结束标记时无法确定配置块在哪里结束，只记录开始标记这一行，
+		// 由调用方拒绝修改，避免删掉配置块后面的用户配置或留下半个旧配置块
+		return blockSpan{i, i + 1, version, true, endMarker}, true
+	}
+	return blockSpan{}, false
+}
+
+// hasBlock 检查内容中是否包含指定 ID 的配置块
+func hasBlock(content, id string) bool {
+	_, found := findBlock(splitLines(content), id)
+	return found
+}
+
+// brokenBlockError 返回配置块缺少结束标记的错误，指出开始标记所在的行，
+// 配置块完整或不存在时返回 nil
+func brokenBlockError(path, content, id string) error {
+	lines := splitLines(content)
+	span, found := findBlock(lines, id)
+	if !found || !span.broken {
+		return nil
+	}
+	return fmt.Errorf(i18n.T("config.err_broken_block"), path, span.start+1, lines[span.start], span.endMarker)
+}
+
+// ApplyBlock 将配置块写入内容：不存在时追加，已过期时原地替换
+// 已有的配置块缺少结束标记时不修改，返回 BlockBroken
+func ApplyBlock(content string, b Block) (string, BlockAction) {
+	lines := splitLines(content)
+	span, found := findBlock(lines, b.ID)
+	if !found {
+		if content == "" {
+			return b.Render(), BlockAdded
+		}
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		return content + "\n" + b.Render(), BlockAdded
+	}
+
+	if span.broken {
+		return content, BlockBroken
+	}
+	if span.version > b.Version {
+		return content, BlockNewer
+	}
+
+	existing := strings.Join(lines[span.start:span.end], "\n") + "\n"
+	if existing == b.Render() {
+		return content, BlockUnchanged
+	}
+
+	rendered := splitLines(b.Render())
+	updated := append(append(append([]string{}, lines[:span.start]...), rendered...), lines[span.end:]...)
+	return joinLines(updated), BlockUpgraded
+}
+
+// RemoveBlock 移除指定 ID 的配置块及安装时添加的前置空行
+// 配置块缺少结束标记时不修改，返回 false
+func RemoveBlock(content, id string) (string, bool) {
+	lines := splitLines(content)
+	span, found := findBlock(lines, id)
+	if !found || span.broken {
+		return content, false
+	}
+
+	start := span.start
+	if start > 0 && strings.TrimSpace(lines[start-1]) == "" {
+		start--
+	}
+	updated := append(append([]string{}, lines[:start]...), lines[span.end:]...)
+	return joinLines(updated), true
+}
+
+// joinLines 将行列表拼回文本，非空时以换行结尾
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,50 @@
+package config
+
+// BlockStatus 描述某个配置文件中 tmx 配置块的安装情况
+type BlockStatus struct {
+	Label     string // tmux、bash、zsh、fish
+	Path      string
+	Installed bool
+	Version   int
+}
+
+// Outdated 检查已安装的配置块是否比当前版本旧
+func (s BlockStatus) Outdated() bool {
+	return s.Installed && s.Version < blockVersion
+}
+
+// InspectInstall 检查 tmux 配置与登录 shell 配置中 tmx 配置块的安装情况，不修改任何文件
+func InspectInstall(opts Options) ([]BlockStatus, error) {
+	homeDir, err := opts.homeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []BlockStatus{inspectBlock("tmux", tmuxConfigPath(homeDir), tmuxBlockID)}
+	for _, target := range shellTargets(homeDir, false) {
+		statuses = append(statuses, inspectBlock(target.Shell, target.Path, shellBlockID))
+	}
+	return statuses, nil
+}
+
+func inspectBlock(label, path, id string) BlockStatus {
+	status := BlockStatus{Label: label, Path: path}
+	content, existed, err := readFile(path)
+	if err != nil || !existed {
+		return status
+	}
+	if span, found := findBlock(splitLines(content), id); found {
+		status.Installed = true
+		status.Version = span.version
+	}
+	return status
+}
+
+// TmuxConfigPath 返回 tmux 实际会加载的用户配置文件
+func TmuxConfigPath(opts Options) (string, error) {
+	homeDir, err := opts.homeDir()
+	if err != nil {
+		return "", err
+	}
+	return tmuxConfigPath(homeDir), nil
+}
@@ -5,286 +5,346 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
 )
 
-const tmuxConfigMarker = "# ========== tmx 配置 =========="
-
-const tmuxConfigContent = `
-# ========== tmx 配置 ==========
-# 按 Ctrl+b t 打开会话管理器
-bind-key t run-shell "tmx"
-
-# 在状态栏显示快捷键提示
-set -g status-right '#[fg=green][Ctrl+B T] 管理器#[default] | %H:%M %Y-%m-%d'
-# ========== tmx 配置结束 ==========
-`
-
-const shellConfigMarker = "# ========== tmx quit 命令 =========="
-
-const bashQuitConfig = `
-# ========== tmx quit 命令 ==========
-# 退出 tmux 会话但保持运行（相当于 Ctrl+b d）
-quit() {
-    if [ -n "$TMUX" ]; then
-        tmux detach-client
-    else
-        echo "不在 tmux 会话中"
-    fi
-}
-# ========== tmx quit 命令结束 ==========
-`
-
-const zshQuitConfig = `
-# ========== tmx quit 命令 ==========
-# 退出 tmux 会话但保持运行（相当于 Ctrl+b d）
-quit() {
-    if [ -n "$TMUX" ]; then
-        tmux detach-client
-    else
-        echo "不在 tmux 会话中"
-    fi
+// 配置块 ID
+const (
+	tmuxBlockID  = "tmux"
+	shellBlockID = "shell"
+)
+
+// 配置块中的注释和状态栏标签使用固定文本，不随界面语言变化，
+// 否则换一个 LANG 运行 --install 会认为配置块有变化而重写并备份
+const (
+	managedComment = "Managed by tmx, do not edit; run tmx --uninstall to remove"
+	bindComment    = "Press Ctrl+b t to open the session manager"
+	shellComment   = "Load the tmx shell integration (quit/detach, quick switch, completion); updates with tmx"
+	statusComment  = "Append the key hint and session stats to the status line (keeps your status-right)"
+	statusLabel    = "manager"
+	notifyComment  = "Desktop notifications when a window rings the bell or goes quiet (tmx notify)"
+)
+
+// Options 控制安装和卸载的行为
+type Options struct {
+	// Home 用户目录，为空时使用 os.UserHomeDir
+	Home string
+	// DryRun 只打印将要做的修改（统一 diff），不写入任何文件
+	DryRun bool
+	// AllShells 将 shell 集成安装到所有检测到的 shell，而不只是登录 shell
+	AllShells bool
 }
-# ========== tmx quit 命令结束 ==========
-`
 
-// InstallConfig 安装 tmux 配置
-func InstallConfig() error {
+// homeDir 返回安装目标所在的用户目录
+func (o Options) homeDir() (string, error) {
+	if o.Home != "" {
+		return o.Home, nil
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("无法获取用户目录: %w", err)
+		return "", fmt.Errorf(i18n.T("config.err_home"), err)
 	}
+	return homeDir, nil
+}
 
-	configPath := filepath.Join(homeDir, ".tmux.conf")
+// tmuxBlock 返回写入 tmux 配置的配置块，status 为状态栏部分（见 statusLines）
+// hooks 为通知钩子（见 notifyHooks），未启用通知时为空
+func tmuxBlock(binding, status, hooks string) Block {
+	body := fmt.Sprintf(`# %s
+# %s
+bind-key t %s
+
+%s`,
+		managedComment,
+		bindComment, binding,
+		status)
+	if hooks != "" {
+		body = strings.TrimRight(body, "\n") + "\n\n" + hooks
+	}
+	return Block{
+		ID:      tmuxBlockID,
+		Version: blockVersion,
+		Body:    body,
+	}
+}
 
-	// 检查配置文件是否存在
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		// 配置文件不存在，创建新文件
-		if err := os.WriteFile(configPath, []byte(tmuxConfigContent), 0644); err != nil {
-			return fmt.Errorf("无法创建配置文件: %w", err)
-		}
-		fmt.Printf("✓ 已创建配置文件: %s\n", configPath)
-	} else {
-		// 配置文件已存在，检查是否已包含 tmx 配置
-		content, err := os.ReadFile(configPath)
-		if err != nil {
-			return fmt.Errorf("无法读取配置文件: %w", err)
+// shellBlock 返回写入 shell 配置的集成代码
+// 只加载 tmx init 的输出，具体内容随 tmx 升级，不固化在 rc 文件中
+func shellBlock(shell string) Block {
+	if shell == shellFish {
+		return Block{
+			ID:      shellBlockID,
+			Version: blockVersion,
+			Body: fmt.Sprintf(`# %s
+# %s
+if type -q tmx
+    tmx init fish | source
+end`, managedComment, shellComment),
 		}
+	}
 
-		// 检查是否已包含配置
-		if contains(string(content), "tmx") {
-			fmt.Println("⚠ tmx 配置已存在，无需重复安装")
-		} else {
-			// 追加配置
-			file, err := os.OpenFile(configPath, os.O_APPEND|os.O_WRONLY, 0644)
-			if err != nil {
-				return fmt.Errorf("无法打开配置文件: %w", err)
-			}
-			defer file.Close()
+	return Block{
+		ID:      shellBlockID,
+		Version: blockVersion,
+		Body: fmt.Sprintf(`# %s
+# %s
+if command -v tmx >/dev/null 2>&1; then
+    eval "$(tmx init %s)"
+fi`, managedComment, shellComment, shell),
+	}
+}
 
-			if _, err := file.WriteString(tmuxConfigContent); err != nil {
-				return fmt.Errorf("无法写入配置: %w", err)
-			}
+// InstallConfig 安装 tmux 配置
+func InstallConfig(settings *Settings, opts Options) error {
+	homeDir, err := opts.homeDir()
+	if err != nil {
+		return err
+	}
+
+	// 根据安装时的 tmux 版本选择弹窗或新窗口
+	version := detectTmuxVersion()
+	binding := bindingCommand(version, settings.Popup)
+	if !version.AtLeast(3, 2) {
+		fmt.Println(i18n.T("install.popup_unsupported", version.String()))
+	}
 
-			fmt.Printf("✓ 已添加配置到: %s\n", configPath)
+	// 记录当前的 status-right，卸载时恢复
+	configPath := tmuxConfigPath(homeDir)
+	existing, _, err := readFile(configPath)
+	if err != nil {
+		return err
+	}
+	status := statusLines(currentStatusRight(existing))
+	if err := installBlock("tmux", configPath, tmuxBlock(binding, status, notifyHooks(settings.Notify, version)), opts); err != nil {
+		return err
+	}
+
+	// 安装 shell 集成，单个 shell 失败不影响其它 shell
+	var sourced []string
+	for _, target := range shellTargets(homeDir, opts.AllShells) {
+		if err := installBlock(target.Shell, target.Path, shellBlock(target.Shell), opts); err != nil {
+			fmt.Println(i18n.T("install.failed", target.Shell, target.Path, err))
+			continue
+		}
+		// 旧版本安装在其它位置的 quit 函数已被 tmx init 取代
+		for _, legacy := range legacyShellConfigs(homeDir, target.Shell) {
+			content, _, err := readFile(legacy)
+			if err == nil && hasBlock(content, shellBlockID) {
+				if err := uninstallBlock(target.Shell, legacy, shellBlockID, opts); err != nil {
+					fmt.Println(i18n.T("install.failed", target.Shell, legacy, err))
+				}
+			}
+		}
+		// fish 自动加载 conf.d 目录，无需 source
+		if target.Shell != shellFish {
+			sourced = append(sourced, target.Path)
 		}
 	}
 
-	// 安装 quit 命令到 shell 配置
-	if err := installQuitCommand(homeDir); err != nil {
-		fmt.Printf("⚠️  安装 quit 命令失败: %v\n", err)
+	if opts.DryRun {
+		fmt.Println(i18n.T("install.dry_run"))
+		return nil
 	}
 
-	fmt.Println("\n请运行以下命令重新加载 tmux 配置：")
-	fmt.Println("  tmux source-file ~/.tmux.conf")
-	fmt.Println("\n或重启 tmux")
+	fmt.Println(i18n.T("install.reload_tmux", configPath))
+	for _, path := range sourced {
+		fmt.Println(i18n.T("install.reload_shell", path))
+	}
 
 	return nil
 }
 
-// installQuitCommand 安装 quit 命令到 shell 配置
-func installQuitCommand(homeDir string) error {
-	// 检测使用的 shell
-	shellConfigPath := ""
-	quitConfig := ""
-
-	// 优先检查 zsh
-	zshrc := filepath.Join(homeDir, ".zshrc")
-	if _, err := os.Stat(zshrc); err == nil {
-		shellConfigPath = zshrc
-		quitConfig = zshQuitConfig
-	}
-
-	// 如果 zsh 不存在，检查 bash
-	if shellConfigPath == "" {
-		bashrc := filepath.Join(homeDir, ".bashrc")
-		if _, err := os.Stat(bashrc); err == nil {
-			shellConfigPath = bashrc
-			quitConfig = bashQuitConfig
-		}
+// installBlock 将配置块写入文件：不存在时追加，过期时原地升级
+// label 用于逐个目标报告结果（tmux、bash、zsh、fish）
+func installBlock(label, path string, block Block, opts Options) error {
+	before, existed, err := readFile(path)
+	if err != nil {
+		return err
 	}
 
-	// 如果都不存在，创建 .bashrc
-	if shellConfigPath == "" {
-		shellConfigPath = filepath.Join(homeDir, ".bashrc")
-		quitConfig = bashQuitConfig
+	after, action := ApplyBlock(before, block)
+	switch action {
+	case BlockBroken:
+		return brokenBlockError(path, before, block.ID)
+	case BlockUnchanged:
+		fmt.Println(i18n.T("install.up_to_date", label, path))
+		return nil
+	case BlockNewer:
+		fmt.Println(i18n.T("install.newer", label, path))
+		return nil
 	}
 
-	// 读取现有配置
-	content, err := os.ReadFile(shellConfigPath)
-	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("无法读取 shell 配置文件: %w", err)
+	if err := writeFile(path, before, after, existed, opts); err != nil {
+		return err
 	}
-
-	// 检查是否已包含 quit 命令
-	if content != nil && contains(string(content), shellConfigMarker) {
-		fmt.Println("✓ quit 命令已安装")
+	if opts.DryRun {
 		return nil
 	}
 
-	// 追加 quit 命令
-	file, err := os.OpenFile(shellConfigPath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
-	if err != nil {
-		return fmt.Errorf("无法打开 shell 配置文件: %w", err)
-	}
-	defer file.Close()
-
-	if _, err := file.WriteString(quitConfig); err != nil {
-		return fmt.Errorf("无法写入 quit 命令: %w", err)
+	switch {
+	case action == BlockUpgraded:
+		fmt.Println(i18n.T("install.upgraded", label, path))
+	case existed:
+		fmt.Println(i18n.T("install.appended", label, path))
+	default:
+		fmt.Println(i18n.T("install.created", label, path))
 	}
-
-	fmt.Printf("✓ 已添加 quit 命令到: %s\n", shellConfigPath)
 	return nil
 }
 
 // UninstallConfig 卸载 tmux 配置
-func UninstallConfig() error {
-	homeDir, err := os.UserHomeDir()
+func UninstallConfig(opts Options) error {
+	homeDir, err := opts.homeDir()
 	if err != nil {
-		return fmt.Errorf("无法获取用户目录: %w", err)
+		return err
 	}
 
-	configPath := filepath.Join(homeDir, ".tmux.conf")
-
-	// 检查配置文件是否存在
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		fmt.Println("⚠ tmux 配置文件不存在，无需卸载")
-	} else {
-		// 读取配置文件
-		content, err := os.ReadFile(configPath)
+	// tmux 配置可能位于任一候选路径
+	found := false
+	original, known := "", false
+	for _, path := range tmuxConfigCandidates(homeDir) {
+		content, existed, err := readFile(path)
 		if err != nil {
-			return fmt.Errorf("无法读取配置文件: %w", err)
+			return err
 		}
+		if !existed {
+			continue
+		}
+		found = true
+		if value, ok := recordedStatusRight(content); ok {
+			original, known = value, true
+		}
+		if err := uninstallBlock("tmux", path, tmuxBlockID, opts); err != nil {
+			return err
+		}
+	}
+	if !found {
+		fmt.Println(i18n.T("uninstall.no_config", "tmux", tmuxConfigPath(homeDir)))
+	}
 
-		// 检查是否包含 tmx 配置
-		if !contains(string(content), tmuxConfigMarker) {
-			fmt.Println("⚠ 未找到 tmx 配置，无需卸载")
-		} else {
-			// 移除 tmx 配置块
-			lines := strings.Split(string(content), "\n")
-			newLines := []string{}
-			inTmxBlock := false
-
-			for _, line := range lines {
-				if strings.Contains(line, tmuxConfigMarker) {
-					inTmxBlock = !inTmxBlock
-					continue
-				}
-				if !inTmxBlock {
-					newLines = append(newLines, line)
-				}
-			}
-
-			// 移除多余的空行
-			newContent := strings.Join(newLines, "\n")
-			newContent = strings.TrimSpace(newContent) + "\n"
-
-			// 写回文件
-			if err := os.WriteFile(configPath, []byte(newContent), 0644); err != nil {
-				return fmt.Errorf("无法写入配置文件: %w", err)
-			}
+	// 立即恢复运行中 tmux 的状态栏，无需重启
+	if !opts.DryRun {
+		restoreStatusRight(original, known)
+	}
 
-			fmt.Printf("✓ 已从 %s 移除 tmx 配置\n", configPath)
+	// 卸载 shell 集成
+	for _, target := range allShellConfigs(homeDir) {
+		if !fileExists(target.Path) {
+			continue
+		}
+		content, _, err := readFile(target.Path)
+		if err != nil || !hasBlock(content, shellBlockID) {
+			continue
+		}
+		if err := uninstallBlock(target.Shell, target.Path, shellBlockID, opts); err != nil {
+			fmt.Println(i18n.T("uninstall.failed", target.Shell, target.Path, err))
 		}
 	}
 
-	// 卸载 quit 命令
-	if err := uninstallQuitCommand(homeDir); err != nil {
-		fmt.Printf("⚠️  卸载 quit 命令失败: %v\n", err)
+	if opts.DryRun {
+		fmt.Println(i18n.T("install.dry_run"))
+		return nil
 	}
 
-	fmt.Println("\n请运行以下命令重新加载 tmux 配置：")
-	fmt.Println("  tmux source-file ~/.tmux.conf")
-	fmt.Println("\n或重启 tmux")
-	fmt.Println("\n并重新加载 shell 配置：")
-	fmt.Println("  source ~/.bashrc  # 或 ~/.zshrc")
+	fmt.Println(i18n.T("install.reload_tmux", tmuxConfigPath(homeDir)))
+	fmt.Println(i18n.T("uninstall.reload_shell"))
 
 	return nil
 }
 
-// uninstallQuitCommand 卸载 quit 命令
-func uninstallQuitCommand(homeDir string) error {
-	shellConfigs := []string{
-		filepath.Join(homeDir, ".zshrc"),
-		filepath.Join(homeDir, ".bashrc"),
+// uninstallBlock 从文件中移除配置块
+func uninstallBlock(label, path, id string, opts Options) error {
+	before, existed, err := readFile(path)
+	if err != nil {
+		return err
+	}
+	if !existed {
+		fmt.Println(i18n.T("uninstall.no_config", label, path))
+		return nil
 	}
 
-	removed := false
-	for _, configPath := range shellConfigs {
-		content, err := os.ReadFile(configPath)
-		if err != nil {
-			continue // 文件不存在，跳过
-		}
-
-		// 检查是否包含 quit 命令
-		if !contains(string(content), shellConfigMarker) {
-			continue
-		}
+	if err := brokenBlockError(path, before, id); err != nil {
+		return err
+	}
+	after, removed := RemoveBlock(before, id)
+	if !removed {
+		fmt.Println(i18n.T("uninstall.not_found", label, path))
+		return nil
+	}
 
-		// 移除 quit 命令块
-		lines := strings.Split(string(content), "\n")
-		newLines := []string{}
-		inQuitBlock := false
+	if err := writeFile(path, before, after, existed, opts); err != nil {
+		return err
+	}
+	if !opts.DryRun {
+		fmt.Println(i18n.T("uninstall.removed", label, path))
+	}
+	return nil
+}
 
-		for _, line := range lines {
-			if strings.Contains(line, shellConfigMarker) {
-				inQuitBlock = !inQuitBlock
-				continue
-			}
-			if !inQuitBlock {
-				newLines = append(newLines, line)
-			}
-		}
+// readFile 读取文件内容，文件不存在时返回空内容
+func readFile(path string) (content string, existed bool, err error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf(i18n.T("config.err_read"), err)
+	}
+	return string(data), true, nil
+}
 
-		// 移除多余的空行
-		newContent := strings.Join(newLines, "\n")
-		newContent = strings.TrimSpace(newContent) + "\n"
+// writeFile 写入修改后的内容
+// dry-run 时只打印统一 diff；否则先为已有文件创建带时间戳的备份
+// 卸载后内容为空的文件会被删除
+func writeFile(path, before, after string, existed bool, opts Options) error {
+	if opts.DryRun {
+		fmt.Print(unifiedDiff(path, before, after))
+		return nil
+	}
 
-		// 写回文件
-		if err := os.WriteFile(configPath, []byte(newContent), 0644); err != nil {
-			return fmt.Errorf("无法写入配置文件: %w", err)
+	mode := os.FileMode(0644)
+	if existed {
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
 		}
-
-		fmt.Printf("✓ 已从 %s 移除 quit 命令\n", configPath)
-		removed = true
+		backup, err := backupFile(path, before, mode)
+		if err != nil {
+			return err
+		}
+		fmt.Println(i18n.T("install.backup", backup))
 	}
 
-	if !removed {
-		fmt.Println("⚠ 未找到 quit 命令配置")
+	if after == "" && existed {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf(i18n.T("config.err_write_file"), err)
+		}
+		return nil
 	}
 
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf(i18n.T("config.err_write_file"), err)
+	}
+	if err := os.WriteFile(path, []byte(after), mode); err != nil {
+		return fmt.Errorf(i18n.T("config.err_write_file"), err)
+	}
 	return nil
 }
 
-// contains 检查字符串是否包含子串
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || findInString(s, substr)))
-}
-
-func findInString(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+// backupFile 将原内容保存为 <path>.tmx-backup-<时间戳>
+func backupFile(path, content string, mode os.FileMode) (string, error) {
+	base := path + ".tmx-backup-" + time.Now().Format("20060102-150405")
+	backup := base
+	for i := 1; ; i++ {
+		if _, err := os.Stat(backup); os.IsNotExist(err) {
+			break
 		}
+		backup = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	if err := os.WriteFile(backup, []byte(content), mode); err != nil {
+		return "", fmt.Errorf(i18n.T("config.err_backup"), err)
 	}
-	return false
+	return backup, nil
 }
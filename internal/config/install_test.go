@@ -0,0 +1,445 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func backups(t *testing.T, path string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(path + ".tmx-backup-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matches
+}
+
+// testHome 创建临时用户目录，并固定登录 shell 与 XDG 路径
+// tmux 的 socket 也指向临时目录，避免读写用户正在运行的 tmux
+func testHome(t *testing.T, shell string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("SHELL", "/bin/"+shell)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("ZDOTDIR", "")
+	t.Setenv("TMUX", "")
+	t.Setenv("TMUX_TMPDIR", home)
+	return home
+}
+
+func TestInstallIntoEmptyHome(t *testing.T) {
+	home := testHome(t, "bash")
+
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatalf("InstallConfig: %v", err)
+	}
+
+	conf := readTestFile(t, filepath.Join(home, ".tmux.conf"))
+	if !strings.HasPrefix(conf, "# >>> tmx:tmux v4 >>>\n") || !strings.HasSuffix(conf, "# <<< tmx:tmux <<<\n") {
+		t.Errorf("unexpected .tmux.conf:\n%s", conf)
+	}
+	if !strings.Contains(readTestFile(t, filepath.Join(home, ".bashrc")), `eval "$(tmx init bash)"`) {
+		t.Error(".bashrc does not load tmx init")
+	}
+	// 新建的文件不需要备份
+	if b := backups(t, filepath.Join(home, ".tmux.conf")); len(b) != 0 {
+		t.Errorf("unexpected backups: %v", b)
+	}
+}
+
+func TestInstallIgnoresUnrelatedTmxMentions(t *testing.T) {
+	home := testHome(t, "bash")
+	confPath := filepath.Join(home, ".tmux.conf")
+	original := "set -g mouse on\n# TODO: try tmx someday\n"
+	writeTestFile(t, confPath, original)
+
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatalf("InstallConfig: %v", err)
+	}
+
+	conf := readTestFile(t, confPath)
+	if !strings.HasPrefix(conf, original+"\n# >>> tmx:tmux v4 >>>") {
+		t.Errorf("block not appended after original content:\n%s", conf)
+	}
+
+	b := backups(t, confPath)
+	if len(b) != 1 {
+		t.Fatalf("expected one backup, got %v", b)
+	}
+	if got := readTestFile(t, b[0]); got != original {
+		t.Errorf("backup content = %q, want %q", got, original)
+	}
+}
+
+func TestInstallIsIdempotent(t *testing.T) {
+	home := testHome(t, "bash")
+	confPath := filepath.Join(home, ".tmux.conf")
+
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+	first := readTestFile(t, confPath)
+
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+	if second := readTestFile(t, confPath); second != first {
+		t.Errorf("second install changed the file:\n%s", unifiedDiff(confPath, first, second))
+	}
+	if b := backups(t, confPath); len(b) != 0 {
+		t.Errorf("unchanged install created backups: %v", b)
+	}
+}
+
+func TestInstallIgnoresLocale(t *testing.T) {
+	home := testHome(t, "bash")
+	confPath := filepath.Join(home, ".tmux.conf")
+	rcPath := filepath.Join(home, ".bashrc")
+	t.Cleanup(func() { i18n.SetLocale(i18n.Chinese) })
+
+	i18n.SetLocale(i18n.English)
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+	conf, rc := readTestFile(t, confPath), readTestFile(t, rcPath)
+
+	// 换一种界面语言再次安装，配置块不应被视为有变化
+	i18n.SetLocale(i18n.Chinese)
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, confPath); got != conf {
+		t.Errorf("install under another locale changed the file:\n%s", unifiedDiff(confPath, conf, got))
+	}
+	if got := readTestFile(t, rcPath); got != rc {
+		t.Errorf("install under another locale changed the file:\n%s", unifiedDiff(rcPath, rc, got))
+	}
+	if b := append(backups(t, confPath), backups(t, rcPath)...); len(b) != 0 {
+		t.Errorf("install under another locale created backups: %v", b)
+	}
+}
+
+func TestInstallUpgradesLegacyBlockInPlace(t *testing.T) {
+	home := testHome(t, "bash")
+	confPath := filepath.Join(home, ".tmux.conf")
+	legacy := `set -g mouse on
+
+# ========== tmx 配置 ==========
+# 按 Ctrl+b t 打开会话管理器
+bind-key t run-shell "tmx"
+# ========== tmx 配置结束 ==========
+
+set -g history-limit 10000
+`
+	writeTestFile(t, confPath, legacy)
+
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+
+	conf := readTestFile(t, confPath)
+	if strings.Contains(conf, "run-shell") || strings.Contains(conf, "========== tmx") {
+		t.Errorf("legacy block not replaced:\n%s", conf)
+	}
+	if !strings.HasPrefix(conf, "set -g mouse on\n\n# >>> tmx:tmux v4 >>>") {
+		t.Errorf("block not upgraded in place:\n%s", conf)
+	}
+	if !strings.HasSuffix(conf, "# <<< tmx:tmux <<<\n\nset -g history-limit 10000\n") {
+		t.Errorf("content after block was not preserved:\n%s", conf)
+	}
+}
+
+func TestInstallDryRunWritesNothing(t *testing.T) {
+	home := testHome(t, "bash")
+	confPath := filepath.Join(home, ".tmux.conf")
+	writeTestFile(t, confPath, "set -g mouse on\n")
+
+	if err := InstallConfig(&Settings{}, Options{Home: home, DryRun: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readTestFile(t, confPath); got != "set -g mouse on\n" {
+		t.Errorf("dry-run modified .tmux.conf: %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".bashrc")); !os.IsNotExist(err) {
+		t.Error("dry-run created .bashrc")
+	}
+	if b := backups(t, confPath); len(b) != 0 {
+		t.Errorf("dry-run created backups: %v", b)
+	}
+}
+
+func TestUninstallRestoresOriginal(t *testing.T) {
+	home := testHome(t, "zsh")
+	confPath := filepath.Join(home, ".tmux.conf")
+	zshrc := filepath.Join(home, ".zshrc")
+	originalConf := "set -g mouse on\nset -g history-limit 10000\n"
+	originalZsh := "export EDITOR=vim\n"
+	writeTestFile(t, confPath, originalConf)
+	writeTestFile(t, zshrc, originalZsh)
+
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+	if err := UninstallConfig(Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readTestFile(t, confPath); got != originalConf {
+		t.Errorf(".tmux.conf after uninstall = %q, want %q", got, originalConf)
+	}
+	if got := readTestFile(t, zshrc); got != originalZsh {
+		t.Errorf(".zshrc after uninstall = %q, want %q", got, originalZsh)
+	}
+}
+
+func TestUninstallRemovesFileCreatedByInstall(t *testing.T) {
+	home := testHome(t, "bash")
+	confPath := filepath.Join(home, ".tmux.conf")
+
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+	if err := UninstallConfig(Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(confPath); !os.IsNotExist(err) {
+		t.Errorf(".tmux.conf still exists after uninstall")
+	}
+}
+
+func TestInstallUsesXDGTmuxConfig(t *testing.T) {
+	home := testHome(t, "bash")
+	xdgConf := filepath.Join(home, ".config", "tmux", "tmux.conf")
+	if err := os.MkdirAll(filepath.Dir(xdgConf), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, xdgConf, "set -g mouse on\n")
+
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(readTestFile(t, xdgConf), "# >>> tmx:tmux v4 >>>") {
+		t.Error("block not installed into XDG tmux.conf")
+	}
+	if _, err := os.Stat(filepath.Join(home, ".tmux.conf")); !os.IsNotExist(err) {
+		t.Error("~/.tmux.conf created although XDG config exists")
+	}
+}
+
+func TestInstallFishIntegration(t *testing.T) {
+	home := testHome(t, "fish")
+	confd := filepath.Join(home, ".config", "fish", "conf.d", "tmx.fish")
+
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+
+	if fn := readTestFile(t, confd); !strings.Contains(fn, "tmx init fish | source") {
+		t.Errorf("unexpected fish integration:\n%s", fn)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".bashrc")); !os.IsNotExist(err) {
+		t.Error(".bashrc created for fish user")
+	}
+
+	// 卸载后删除整个文件
+	if err := UninstallConfig(Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(confd); !os.IsNotExist(err) {
+		t.Error("tmx.fish still exists after uninstall")
+	}
+}
+
+func TestInstallMigratesLegacyQuitFunction(t *testing.T) {
+	home := testHome(t, "fish")
+	legacyFish := filepath.Join(home, ".config", "fish", "functions", "quit.fish")
+	if err := os.MkdirAll(filepath.Dir(legacyFish), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, legacyFish, "# >>> tmx:quit v3 >>>\nfunction quit\nend\n# <<< tmx:quit <<<\n")
+
+	// bash 中的旧 quit 块原地升级为 tmx init
+	bashrc := filepath.Join(home, ".bashrc")
+	writeTestFile(t, bashrc, "alias ll='ls -l'\n\n# >>> tmx:quit v3 >>>\nquit() {\n    tmux detach-client\n}\n# <<< tmx:quit <<<\n")
+
+	if err := InstallConfig(&Settings{}, Options{Home: home, AllShells: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(legacyFish); !os.IsNotExist(err) {
+		t.Error("legacy quit.fish not removed")
+	}
+	got := readTestFile(t, bashrc)
+	if strings.Contains(got, "tmx:quit") || strings.Count(got, "# >>> tmx:shell v4 >>>") != 1 {
+		t.Errorf("legacy quit block not upgraded:\n%s", got)
+	}
+	if !strings.HasPrefix(got, "alias ll='ls -l'\n\n# >>> tmx:shell v4 >>>") {
+		t.Errorf("block not upgraded in place:\n%s", got)
+	}
+}
+
+func TestInstallAllShells(t *testing.T) {
+	home := testHome(t, "zsh")
+	writeTestFile(t, filepath.Join(home, ".bash_profile"), "export PATH=$HOME/bin:$PATH\n")
+	if err := os.MkdirAll(filepath.Join(home, ".config", "fish"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InstallConfig(&Settings{}, Options{Home: home, AllShells: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{
+		filepath.Join(home, ".zshrc"),
+		filepath.Join(home, ".bash_profile"),
+		filepath.Join(home, ".config", "fish", "conf.d", "tmx.fish"),
+	} {
+		if !strings.Contains(readTestFile(t, path), "# >>> tmx:shell v4 >>>") {
+			t.Errorf("shell block missing from %s", path)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(home, ".bashrc")); !os.IsNotExist(err) {
+		t.Error(".bashrc created although .bash_profile exists")
+	}
+}
+
+func TestInstallAppendsToLiveStatusRight(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	home := testHome(t, "bash")
+	confPath := filepath.Join(home, ".tmux.conf")
+
+	tmux := func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("tmux", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("tmux %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSuffix(string(out), "\n")
+	}
+	tmux("-f", "/dev/null", "new-session", "-d", "-s", "test")
+	t.Cleanup(func() { exec.Command("tmux", "kill-server").Run() })
+
+	original := "#[fg=red]%H:%M"
+	tmux("set-option", "-g", "status-right", original)
+
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := recordedStatusRight(readTestFile(t, confPath)); !ok || got != original {
+		t.Errorf("recorded status-right = %q, %v; want %q", got, ok, original)
+	}
+
+	// 重复 source 只追加一次
+	tmux("source-file", confPath)
+	tmux("source-file", confPath)
+	live := tmux("show-options", "-gv", "status-right")
+	if live != original+statusSegment() {
+		t.Errorf("status-right after source = %q", live)
+	}
+
+	// 重新安装时记录的仍是原始值
+	if err := InstallConfig(&Settings{}, Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := recordedStatusRight(readTestFile(t, confPath)); got != original {
+		t.Errorf("recorded status-right after reinstall = %q", got)
+	}
+
+	if err := UninstallConfig(Options{Home: home}); err != nil {
+		t.Fatal(err)
+	}
+	if live := tmux("show-options", "-gv", "status-right"); live != original {
+		t.Errorf("status-right after uninstall = %q, want %q", live, original)
+	}
+}
+
+func TestApplyBlockSkipsNewerVersion(t *testing.T) {
+	content := "# >>> tmx:tmux v99 >>>\nfuture\n# <<< tmx:tmux <<<\n"
+	got, action := ApplyBlock(content, Block{ID: tmuxBlockID, Version: blockVersion, Body: "now"})
+	if action != BlockNewer || got != content {
+		t.Errorf("ApplyBlock = (%q, %v), want unchanged BlockNewer", got, action)
+	}
+}
+
+func TestInstallRefusesBlockWithoutEndMarker(t *testing.T) {
+	home := testHome(t, "bash")
+	confPath := filepath.Join(home, ".tmux.conf")
+	// 结束标记被误删，后面是用户自己的配置
+	original := "set -g mouse on\n\n# >>> tmx:tmux v3 >>>\nbind-key t run-shell tmx\nset -g base-index 1\n"
+	writeTestFile(t, confPath, original)
+
+	err := InstallConfig(&Settings{}, Options{Home: home})
+	if err == nil || !strings.Contains(err.Error(), confPath) || !strings.Contains(err.Error(), "# <<< tmx:tmux <<<") {
+		t.Fatalf("InstallConfig error = %v, want the broken block reported", err)
+	}
+	if got := readTestFile(t, confPath); got != original {
+		t.Errorf("broken block was modified:\n%s", got)
+	}
+	if b := backups(t, confPath); len(b) != 0 {
+		t.Errorf("unexpected backups: %v", b)
+	}
+
+	if err := UninstallConfig(Options{Home: home}); err == nil {
+		t.Error("UninstallConfig should refuse the broken block")
+	}
+	if got := readTestFile(t, confPath); got != original {
+		t.Errorf("uninstall modified the broken block:\n%s", got)
+	}
+}
+
+func TestApplyBlockLegacyWithoutEndMarker(t *testing.T) {
+	content := "# ========== tmx 配置 ==========\nbind-key t run-shell tmx\n"
+	got, action := ApplyBlock(content, Block{ID: tmuxBlockID, Version: blockVersion, Body: "now"})
+	if action != BlockBroken || got != content {
+		t.Errorf("ApplyBlock = (%q, %v), want unchanged BlockBroken", got, action)
+	}
+	if err := brokenBlockError("f", content, tmuxBlockID); err == nil || !strings.Contains(err.Error(), "tmx 配置结束") {
+		t.Errorf("brokenBlockError = %v, want the legacy end marker", err)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	before := "a\nb\nc\n"
+	after := "a\nb\nc\n\nd\n"
+	want := `--- f
++++ f
+@@ -1,3 +1,5 @@
+ a
+ b
+ c
++
++d
+`
+	if got := unifiedDiff("f", before, after); got != want {
+		t.Errorf("unifiedDiff =\n%s\nwant\n%s", got, want)
+	}
+	if got := unifiedDiff("f", before, before); got != "" {
+		t.Errorf("unifiedDiff of equal content = %q, want empty", got)
+	}
+}
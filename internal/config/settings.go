@@ -0,0 +1,235 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+)
+
+// Settings 是 tmx 的用户配置，保存在 ~/.config/tmx/config.json
+type Settings struct {
+	// Language 界面语言（en / zh-CN），为空时从 LANG/LC_MESSAGES 检测
+	Language string `json:"language,omitempty"`
+
+	// Theme 配色主题名称，优先于自动选择；为空或 auto 时依次根据 NO_COLOR、COLORFGBG、终端背景色选择
+	Theme string `json:"theme,omitempty"`
+
+	// Themes 用户自定义主题，键为主题名称
+	Themes map[string]ThemeConfig `json:"themes,omitempty"`
+
+	// Popup 控制 Ctrl+b t 打开管理器的方式
+	Popup PopupConfig `json:"popup,omitempty"`
+
+	// Init 控制 tmx init 生成的 shell 集成代码
+	Init InitConfig `json:"init,omitempty"`
+
+	// Export 控制滚动历史的导出
+	Export ExportConfig `json:"export,omitempty"`
+
+	// Record 控制窗格输出的录制
+	Record RecordConfig `json:"record,omitempty"`
+
+	// Notify 控制窗口响铃、静默等提醒的桌面通知
+	Notify NotifyConfig `json:"notify,omitempty"`
+
+	// Watch 控制 tmx watch 等待的命令结束后的提醒方式
+	Watch WatchConfig `json:"watch,omitempty"`
+
+	// Reap 控制 tmx reap 清理空闲会话的策略
+	Reap ReapConfig `json:"reap,omitempty"`
+
+	// Archive 控制清理和休眠的会话保存的位置
+	Archive ArchiveConfig `json:"archive,omitempty"`
+}
+
+// ExportConfig 描述滚动历史导出的位置
+type ExportConfig struct {
+	// Dir 导出文件的目录，支持 ~，默认 $XDG_DATA_HOME/tmx/exports
+	Dir string `json:"dir,omitempty"`
+}
+
+// InitConfig 描述 tmx init 的默认选项，命令行参数优先
+type InitConfig struct {
+	// AutoAttach 终端启动时自动连接 tmux
+	AutoAttach bool `json:"auto_attach,omitempty"`
+	// Alias 快速切换命令的名称，默认 t，设为 "none" 不生成
+	Alias string `json:"alias,omitempty"`
+}
+
+// PopupConfig 描述 display-popup 的外观
+type PopupConfig struct {
+	// Mode 为 auto（默认，tmux >= 3.2 使用弹窗）、popup 或 window
+	Mode   string `json:"mode,omitempty"`
+	Width  string `json:"width,omitempty"`
+	Height string `json:"height,omitempty"`
+	// Border 弹窗边框样式（single、rounded、double、heavy、simple、padded、none），需要 tmux >= 3.3
+	Border string `json:"border,omitempty"`
+}
+
+// ThemeConfig 描述一个用户自定义主题
+// 未设置的颜色沿用 Base 指定的内置主题
+type ThemeConfig struct {
+	Base       string `json:"base,omitempty"`
+	Title      string `json:"title,omitempty"`
+	SelectedFg string `json:"selected_fg,omitempty"`
+	SelectedBg string `json:"selected_bg,omitempty"`
+	Hint       string `json:"hint,omitempty"`
+	Accent     string `json:"accent,omitempty"`
+}
+
+// SettingsPath 返回用户配置文件路径，优先使用 $XDG_CONFIG_HOME
+func SettingsPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "tmx", "config.json"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf(i18n.T("config.err_home"), err)
+	}
+	return filepath.Join(homeDir, ".config", "tmx", "config.json"), nil
+}
+
+// ExportDir 返回滚动历史导出的目录
+func (s *Settings) ExportDir() (string, error) {
+	if s.Export.Dir != "" {
+		return expandHome(s.Export.Dir)
+	}
+	return dataDir("exports")
+}
+
+// dataDir 返回 tmx 数据目录下的子目录，优先使用 $XDG_DATA_HOME
+func dataDir(name string) (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "tmx", name), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf(i18n.T("config.err_home"), err)
+	}
+	return filepath.Join(homeDir, ".local", "share", "tmx", name), nil
+}
+
+// expandHome 将路径开头的 ~ 展开为用户主目录
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf(i18n.T("config.err_home"), err)
+	}
+	return filepath.Join(homeDir, path[1:]), nil
+}
+
+// RecordConfig 描述窗格录制的位置和格式
+type RecordConfig struct {
+	// Dir 录制文件的目录，支持 ~，默认 $XDG_DATA_HOME/tmx/recordings
+	Dir string `json:"dir,omitempty"`
+	// Cast 同时写入 asciicast v2 文件，可以用 asciinema 播放
+	Cast bool `json:"cast,omitempty"`
+}
+
+// NotifyConfig 描述桌面通知，启用后 tmx --install 写入 tmux 钩子
+type NotifyConfig struct {
+	// Enabled 窗口提醒时调用 tmx notify 发送通知
+	Enabled bool `json:"enabled,omitempty"`
+	// Command 发送通知的命令，由 sh -c 执行，$1 为标题，$2 为内容
+	// 为空时自动选择 osascript（macOS）或 notify-send
+	Command string `json:"command,omitempty"`
+	// Events 触发通知的提醒（bell、silence、activity），默认 bell 和 silence
+	Events []string `json:"events,omitempty"`
+}
+
+// NotifyEvents 返回触发通知的提醒类型
+func (n NotifyConfig) NotifyEvents() []string {
+	if len(n.Events) == 0 {
+		return []string{"bell", "silence"}
+	}
+	return n.Events
+}
+
+// WatchConfig 描述命令结束后的提醒方式
+type WatchConfig struct {
+	// Alerts 提醒方式：notify（桌面通知，使用 notify.command）、status（tmx status 中显示），默认两者都有
+	Alerts []string `json:"alerts,omitempty"`
+	// Hook 命令结束后由 sh -c 执行的命令，可以使用环境变量
+	// TMX_TARGET、TMX_COMMAND、TMX_STATUS（未知时为空）、TMX_DURATION（秒）
+	Hook string `json:"hook,omitempty"`
+}
+
+// WatchAlerts 返回命令结束后的提醒方式
+func (w WatchConfig) WatchAlerts() []string {
+	if len(w.Alerts) == 0 {
+		return []string{"notify", "status"}
+	}
+	return w.Alerts
+}
+
+// ReapConfig 描述哪些会话可以被 tmx reap 清理，会话需要同时满足所有条件
+type ReapConfig struct {
+	// IdleDays 会话超过多少天没有输出也没有被连接，默认 7
+	IdleDays int `json:"idle_days,omitempty"`
+	// Match 只清理名称匹配其中一个模式的会话（例如 "tmp-*"），为空时不限制
+	Match []string `json:"match,omitempty"`
+	// Pinned 永远保留的会话名称或模式，TUI 中按 P 也可以标记保留
+	Pinned []string `json:"pinned,omitempty"`
+}
+
+// Idle 返回会话被视为空闲的时长
+func (r ReapConfig) Idle() time.Duration {
+	days := r.IdleDays
+	if days <= 0 {
+		days = 7
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// ArchiveConfig 描述会话存档的位置
+type ArchiveConfig struct {
+	// Dir 存档目录，支持 ~，默认 $XDG_DATA_HOME/tmx/archive
+	Dir string `json:"dir,omitempty"`
+}
+
+// ArchiveDir 返回会话存档目录
+func (s *Settings) ArchiveDir() (string, error) {
+	if s.Archive.Dir != "" {
+		return expandHome(s.Archive.Dir)
+	}
+	return dataDir("archive")
+}
+
+// RecordDir 返回窗格录制文件的目录
+func (s *Settings) RecordDir() (string, error) {
+	if s.Record.Dir != "" {
+		return expandHome(s.Record.Dir)
+	}
+	return dataDir("recordings")
+}
+
+// LoadSettings 读取用户配置，文件不存在时返回默认配置
+func LoadSettings() (*Settings, error) {
+	settings := &Settings{}
+
+	path, err := SettingsPath()
+	if err != nil {
+		return settings, err
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, fmt.Errorf(i18n.T("config.err_read_settings"), path, err)
+	}
+
+	if err := json.Unmarshal(content, settings); err != nil {
+		return settings, fmt.Errorf(i18n.T("config.err_parse_settings"), path, err)
+	}
+	return settings, nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// statusOriginalPrefix 是配置块中记录原始 status-right 的注释前缀
+const statusOriginalPrefix = "# original status-right: "
+
+// statusSegmentPattern 匹配 tmx 追加到 status-right 的片段（任意语言的标签）
+var statusSegmentPattern = regexp.MustCompile(` ?#\[fg=green\]\[Ctrl\+B T\][^#]*#\[default\] #\(tmx status "#\{session_name\}"\)`)
+
+// statusSegment 返回追加到 status-right 的片段，#(tmx status) 输出动态信息
+func statusSegment() string {
+	return fmt.Sprintf(` #[fg=green][Ctrl+B T] %s#[default] #(tmx status "#{session_name}")`, statusLabel)
+}
+
+// statusLines 返回配置块中的状态栏配置
+// 使用 set -ga 追加而不是覆盖 status-right，并用 if-shell 防止重复 source 时多次追加
+func statusLines(original string, known bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", statusComment)
+	if known {
+		b.WriteString(statusOriginalPrefix + strconv.Quote(original) + "\n")
+	}
+	fmt.Fprintf(&b, `if-shell -F '#{m:*tmx status*,#{status-right}}' '' "set -ga status-right '%s'"`,
+		strings.ReplaceAll(statusSegment(), `"`, `\"`))
+	return b.String()
+}
+
+// stripStatusSegment 从 status-right 中移除 tmx 片段
+func stripStatusSegment(value string) string {
+	return statusSegmentPattern.ReplaceAllString(value, "")
+}
+
+// recordedStatusRight 从配置内容中读取安装时记录的原始 status-right
+func recordedStatusRight(content string) (string, bool) {
+	for _, line := range splitLines(content) {
+		if !strings.HasPrefix(line, statusOriginalPrefix) {
+			continue
+		}
+		value, err := strconv.Unquote(strings.TrimPrefix(line, statusOriginalPrefix))
+		if err == nil {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// currentStatusRight 读取运行中 tmux 的 status-right（去掉 tmx 片段）
+// tmux 未运行时回退到配置文件中已记录的值
+func currentStatusRight(content string) (string, bool) {
+	if value, err := tmux.NewManager().GlobalOption("status-right"); err == nil {
+		return stripStatusSegment(value), true
+	}
+	return recordedStatusRight(content)
+}
+
+// restoreStatusRight 卸载后恢复运行中 tmux 的 status-right
+// 优先使用安装时记录的原始值，否则只移除 tmx 片段
+func restoreStatusRight(recorded string, known bool) {
+	manager := tmux.NewManager()
+	value, err := manager.GlobalOption("status-right")
+	if err != nil {
+		return // tmux 未运行，重启后自然恢复
+	}
+	if !known {
+		recorded = stripStatusSegment(value)
+	}
+	if recorded == value {
+		return
+	}
+	if err := manager.SetGlobalOption("status-right", recorded); err != nil {
+		fmt.Println(i18n.T("uninstall.status_failed", err))
+		return
+	}
+	fmt.Println(i18n.T("uninstall.status_restored"))
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"bufio"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// 支持安装 shell 集成的 shell
+const (
+	shellBash = "bash"
+	shellZsh  = "zsh"
+	shellFish = "fish"
+)
+
+var supportedShells = []string{shellBash, shellZsh, shellFish}
+
+// shellTarget 是 shell 集成在某个 shell 中的安装位置
+type shellTarget struct {
+	Shell string
+	Path  string
+}
+
+// tmuxConfigCandidates 返回 tmux 查找用户配置的路径，顺序与 tmux 一致
+// tmux >= 3.1 在 ~/.tmux.conf 不存在时会读取 XDG 路径
+func tmuxConfigCandidates(homeDir string) []string {
+	candidates := []string{filepath.Join(homeDir, ".tmux.conf")}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		candidates = append(candidates, filepath.Join(dir, "tmux", "tmux.conf"))
+	}
+	return append(candidates, filepath.Join(homeDir, ".config", "tmux", "tmux.conf"))
+}
+
+// tmuxConfigPath 返回 tmux 实际会加载的配置文件，都不存在时使用 ~/.tmux.conf
+func tmuxConfigPath(homeDir string) string {
+	candidates := tmuxConfigCandidates(homeDir)
+	for _, path := range candidates {
+		if fileExists(path) {
+			return path
+		}
+	}
+	return candidates[0]
+}
+
+// detectLoginShell 返回用户登录 shell 的名称（bash、zsh、fish 等）
+func detectLoginShell() string {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = passwdShell()
+	}
+	return filepath.Base(shell)
+}
+
+// passwdShell 从 /etc/passwd 读取当前用户的登录 shell
+func passwdShell() string {
+	u, err := user.Current()
+	if err != nil {
+		return ""
+	}
+	file, err := os.Open("/etc/passwd")
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) == 7 && fields[0] == u.Username {
+			return fields[6]
+		}
+	}
+	return ""
+}
+
+// shellConfigCandidates 返回 shell 的配置文件，第一个为首选
+func shellConfigCandidates(homeDir, shell string) []string {
+	switch shell {
+	case shellBash:
+		return []string{
+			filepath.Join(homeDir, ".bashrc"),
+			filepath.Join(homeDir, ".bash_profile"),
+			filepath.Join(homeDir, ".bash_login"),
+			filepath.Join(homeDir, ".profile"),
+		}
+	case shellZsh:
+		zdotdir := os.Getenv("ZDOTDIR")
+		if zdotdir == "" {
+			zdotdir = homeDir
+		}
+		return []string{
+			filepath.Join(zdotdir, ".zshrc"),
+			filepath.Join(zdotdir, ".zprofile"),
+		}
+	case shellFish:
+		// fish 启动时自动加载 conf.d 目录
+		return []string{filepath.Join(fishConfigDir(homeDir), "conf.d", "tmx.fish")}
+	}
+	return nil
+}
+
+// shellConfigPath 选择 shell 的安装位置：首个已存在的配置文件，都不存在时使用首选文件
+func shellConfigPath(homeDir, shell string) string {
+	candidates := shellConfigCandidates(homeDir, shell)
+	for _, path := range candidates {
+		if fileExists(path) {
+			return path
+		}
+	}
+	return candidates[0]
+}
+
+// shellTargets 返回要安装 shell 集成的位置
+// 默认只安装到登录 shell；all 为 true 时安装到登录 shell 以及所有已有配置文件的 shell
+func shellTargets(homeDir string, all bool) []shellTarget {
+	login := detectLoginShell()
+	if !isSupportedShell(login) {
+		// 无法识别的登录 shell 沿用旧行为：有 .zshrc 用 zsh，否则 bash
+		login = shellBash
+		if fileExists(filepath.Join(homeDir, ".zshrc")) {
+			login = shellZsh
+		}
+	}
+
+	targets := []shellTarget{{login, shellConfigPath(homeDir, login)}}
+	if !all {
+		return targets
+	}
+
+	for _, shell := range supportedShells {
+		if shell == login {
+			continue
+		}
+		for _, path := range shellConfigCandidates(homeDir, shell) {
+			// fish 以 ~/.config/fish 目录是否存在判断
+			if fileExists(path) || (shell == shellFish && fileExists(filepath.Dir(filepath.Dir(path)))) {
+				targets = append(targets, shellTarget{shell, shellConfigPath(homeDir, shell)})
+				break
+			}
+		}
+	}
+	return targets
+}
+
+// legacyShellConfigs 返回旧版本安装 quit 函数、现已不再使用的位置
+func legacyShellConfigs(homeDir, shell string) []string {
+	if shell == shellFish {
+		return []string{filepath.Join(fishConfigDir(homeDir), "functions", "quit.fish")}
+	}
+	return nil
+}
+
+// allShellConfigs 返回所有可能包含 shell 集成的文件，用于卸载
+func allShellConfigs(homeDir string) []shellTarget {
+	var targets []shellTarget
+	for _, shell := range supportedShells {
+		paths := append(shellConfigCandidates(homeDir, shell), legacyShellConfigs(homeDir, shell)...)
+		for _, path := range paths {
+			targets = append(targets, shellTarget{shell, path})
+		}
+	}
+	return targets
+}
+
+// fishConfigDir 返回 fish 的配置目录
+func fishConfigDir(homeDir string) string {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configDir, "fish")
+}
+
+func isSupportedShell(shell string) bool {
+	for _, s := range supportedShells {
+		if s == shell {
+			return true
+		}
+	}
+	return false
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,288 @@
+// Package doctor 检查 tmx 的运行环境并给出修复建议
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/shell"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// Status 是单项检查的结果
+type Status int
+
+const (
+	OK Status = iota
+	Warn
+	Fail
+)
+
+// Check 是一项诊断结果
+type Check struct {
+	Name   string
+	Status Status
+	Detail string
+	Fix    string // 可执行的修复建议，为空表示无需处理
+}
+
+// Run 执行全部检查
+func Run() []Check {
+	manager := tmux.NewManager()
+	var checks []Check
+
+	// tmux 可执行文件与版本，缺失时后续检查没有意义
+	binary := checkBinary()
+	checks = append(checks, binary)
+	if binary.Status == Fail {
+		return append(checks, checkTerminal()...)
+	}
+	checks = append(checks, checkVersion(manager.Version()))
+
+	server := checkServer(manager)
+	checks = append(checks, server, checkTmuxEnv(), checkSocket())
+	checks = append(checks, checkTerminal()...)
+	checks = append(checks, checkInstall(config.Options{}, manager.KeyBinding, server.Status == OK)...)
+	return checks
+}
+
+// Print 输出检查结果，返回失败项数量
+func Print(w io.Writer, checks []Check) int {
+	failures, warnings := 0, 0
+	fmt.Fprintln(w, i18n.T("doctor.title"))
+	fmt.Fprintln(w)
+	for _, c := range checks {
+		icon := "✓"
+		switch c.Status {
+		case Warn:
+			icon = "⚠"
+			warnings++
+		case Fail:
+			icon = "✗"
+			failures++
+		}
+		fmt.Fprintf(w, "%s %s: %s\n", icon, c.Name, c.Detail)
+		if c.Fix != "" && c.Status != OK {
+			fmt.Fprintf(w, "    → %s\n", c.Fix)
+		}
+	}
+	fmt.Fprintln(w)
+	if failures == 0 && warnings == 0 {
+		fmt.Fprintln(w, i18n.T("doctor.all_ok"))
+	} else {
+		fmt.Fprintln(w, i18n.T("doctor.summary", failures, warnings))
+	}
+	return failures
+}
+
+func checkBinary() Check {
+	c := Check{Name: i18n.T("doctor.binary")}
+	path, err := exec.LookPath("tmux")
+	if err != nil {
+		c.Status = Fail
+		c.Detail = i18n.T("doctor.binary_missing")
+		c.Fix = i18n.T("doctor.binary_fix")
+		return c
+	}
+	c.Detail = path
+	return c
+}
+
+func checkVersion(version tmux.Version, err error) Check {
+	c := Check{Name: i18n.T("doctor.version")}
+	switch {
+	case err != nil:
+		c.Status = Fail
+		c.Detail = err.Error()
+		c.Fix = i18n.T("doctor.binary_fix")
+	case !version.AtLeast(3, 2):
+		c.Status = Warn
+		c.Detail = i18n.T("doctor.version_old", version.Raw)
+		c.Fix = i18n.T("doctor.version_fix")
+	default:
+		c.Detail = version.Raw
+	}
+	return c
+}
+
+func checkServer(manager *tmux.Manager) Check {
+	c := Check{Name: i18n.T("doctor.server")}
+	socket, pid, err := manager.ServerInfo()
+	if err != nil {
+		c.Status = Warn
+		c.Detail = i18n.T("doctor.server_down")
+		c.Fix = i18n.T("doctor.server_fix")
+		return c
+	}
+	c.Detail = i18n.T("doctor.server_up", pid, socket)
+	return c
+}
+
+func checkTmuxEnv() Check {
+	c := Check{Name: "$TMUX"}
+	env := os.Getenv("TMUX")
+	if env == "" {
+		c.Status = Warn
+		c.Detail = i18n.T("doctor.tmux_env_unset")
+		c.Fix = i18n.T("doctor.tmux_env_unset_fix")
+		return c
+	}
+	socket := tmux.SocketPath()
+	if _, err := os.Stat(socket); err != nil {
+		c.Status = Fail
+		c.Detail = i18n.T("doctor.tmux_env_stale", socket)
+		c.Fix = i18n.T("doctor.tmux_env_stale_fix")
+		return c
+	}
+	c.Detail = env
+	return c
+}
+
+func checkSocket() Check {
+	c := Check{Name: i18n.T("doctor.socket")}
+	socket := tmux.SocketPath()
+	info, err := os.Stat(socket)
+	if err != nil {
+		c.Status = Warn
+		c.Detail = i18n.T("doctor.socket_missing", socket)
+		c.Fix = i18n.T("doctor.server_fix")
+		return c
+	}
+
+	if uid, ok := fileOwner(info); ok && uid != os.Getuid() {
+		c.Status = Fail
+		c.Detail = i18n.T("doctor.socket_owner", socket, uid)
+		c.Fix = i18n.T("doctor.socket_owner_fix", socket)
+		return c
+	}
+
+	dir := filepath.Dir(socket)
+	if dirInfo, err := os.Stat(dir); err == nil && dirInfo.Mode().Perm()&0077 != 0 {
+		c.Status = Fail
+		c.Detail = i18n.T("doctor.socket_dir_mode", dir, dirInfo.Mode().Perm())
+		c.Fix = i18n.T("doctor.socket_dir_fix", dir)
+		return c
+	}
+
+	c.Detail = socket
+	return c
+}
+
+func checkTerminal() []Check {
+	term := os.Getenv("TERM")
+	termCheck := Check{Name: "TERM", Detail: term}
+	switch {
+	case term == "" || term == "dumb":
+		termCheck.Status = Fail
+		termCheck.Detail = i18n.T("doctor.term_unusable", term)
+		termCheck.Fix = i18n.T("doctor.term_fix")
+	case os.Getenv("TMUX") != "" && !strings.HasPrefix(term, "screen") && !strings.HasPrefix(term, "tmux"):
+		termCheck.Status = Warn
+		termCheck.Detail = i18n.T("doctor.term_inside_tmux", term)
+		termCheck.Fix = i18n.T("doctor.term_inside_tmux_fix")
+	}
+
+	colorCheck := Check{Name: i18n.T("doctor.colors")}
+	colorterm := strings.ToLower(os.Getenv("COLORTERM"))
+	switch {
+	case os.Getenv("NO_COLOR") != "":
+		colorCheck.Detail = i18n.T("doctor.colors_disabled")
+	case colorterm == "truecolor" || colorterm == "24bit":
+		colorCheck.Detail = i18n.T("doctor.colors_truecolor")
+	case strings.Contains(term, "256color"):
+		colorCheck.Detail = i18n.T("doctor.colors_256")
+	default:
+		colorCheck.Status = Warn
+		colorCheck.Detail = i18n.T("doctor.colors_basic")
+		colorCheck.Fix = i18n.T("doctor.colors_fix")
+	}
+
+	utf8Check := Check{Name: "UTF-8"}
+	locale := firstNonEmpty(os.Getenv("LC_ALL"), os.Getenv("LC_CTYPE"), os.Getenv("LANG"))
+	lower := strings.ToLower(locale)
+	if strings.Contains(lower, "utf-8") || strings.Contains(lower, "utf8") {
+		utf8Check.Detail = locale
+	} else {
+		utf8Check.Status = Warn
+		utf8Check.Detail = i18n.T("doctor.utf8_missing", locale)
+		utf8Check.Fix = i18n.T("doctor.utf8_fix")
+	}
+
+	return []Check{termCheck, colorCheck, utf8Check}
+}
+
+// checkInstall 检查配置块是否安装、是否最新，以及运行中的 tmux 和当前 shell 是否已加载
+// keyBinding 查询运行中 tmux 的按键绑定，参数与 tmux.Manager.KeyBinding 相同
+func checkInstall(opts config.Options, keyBinding func(table, key string) string, serverUp bool) []Check {
+	statuses, err := config.InspectInstall(opts)
+	if err != nil {
+		return []Check{{Name: i18n.T("doctor.install"), Status: Fail, Detail: err.Error()}}
+	}
+
+	var checks []Check
+	for _, s := range statuses {
+		c := Check{Name: i18n.T("doctor.install_block", s.Label)}
+		switch {
+		case !s.Installed:
+			c.Status = Warn
+			c.Detail = i18n.T("doctor.install_missing", s.Path)
+			c.Fix = i18n.T("doctor.install_fix")
+		case s.Outdated():
+			c.Status = Warn
+			c.Detail = i18n.T("doctor.install_outdated", s.Path, s.Version)
+			c.Fix = i18n.T("doctor.install_fix")
+		default:
+			c.Detail = s.Path
+		}
+		checks = append(checks, c)
+
+		// tmux 配置块已写入但运行中的服务器未加载
+		if s.Label == "tmux" && s.Installed && serverUp {
+			sourced := Check{Name: i18n.T("doctor.sourced")}
+			if binding := keyBinding("prefix", "t"); strings.Contains(binding, "tmx") {
+				sourced.Detail = i18n.T("doctor.sourced_ok")
+			} else {
+				sourced.Status = Warn
+				sourced.Detail = i18n.T("doctor.sourced_missing")
+				sourced.Fix = i18n.T("doctor.sourced_fix", s.Path)
+			}
+			checks = append(checks, sourced)
+		}
+
+		// shell 配置块已写入，但运行 doctor 的 shell 没有加载（例如修改后没有打开新终端）
+		if s.Label != "tmux" && s.Installed {
+			checks = append(checks, checkShellLoaded(s))
+		}
+	}
+	return checks
+}
+
+// checkShellLoaded 检查当前 shell 是否已加载 tmx init 的集成代码
+// 集成代码导出加载它的 shell 的 PID，doctor 由该 shell 直接运行时与父进程一致
+func checkShellLoaded(s config.BlockStatus) Check {
+	c := Check{Name: i18n.T("doctor.shell_loaded", s.Label)}
+	if pid, err := strconv.Atoi(os.Getenv(shell.LoadedEnv)); err == nil && pid == os.Getppid() {
+		c.Detail = i18n.T("doctor.shell_loaded_ok")
+		return c
+	}
+	c.Status = Warn
+	c.Detail = i18n.T("doctor.shell_loaded_missing")
+	c.Fix = i18n.T("doctor.shell_loaded_fix", s.Path)
+	return c
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,312 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/DreamCats/tmuxmanager/internal/shell"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+func TestCheckBinary(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "tmux"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		path string
+		want Status
+	}{
+		{"found", bin, OK},
+		{"missing", t.TempDir(), Fail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PATH", tt.path)
+			if got := checkBinary(); got.Status != tt.want {
+				t.Errorf("checkBinary() = %+v, want status %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		output string
+		err    error
+		want   Status
+	}{
+		{"tmux 3.4", nil, OK},
+		{"tmux 3.2a", nil, OK},
+		{"tmux next-3.5", nil, OK},
+		{"tmux 3.1c", nil, Warn},
+		{"tmux 2.9", nil, Warn},
+		{"", errors.New("failed to run tmux -V"), Fail},
+	}
+	for _, tt := range tests {
+		version, _ := tmux.ParseVersion(tt.output)
+		if got := checkVersion(version, tt.err); got.Status != tt.want {
+			t.Errorf("checkVersion(%q, %v) = %+v, want status %v", tt.output, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCheckServer(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	// 使用临时目录中的 socket，避免读写用户正在运行的 tmux
+	t.Setenv("TMUX", "")
+	t.Setenv("TMUX_TMPDIR", t.TempDir())
+	t.Cleanup(func() { exec.Command("tmux", "kill-server").Run() })
+
+	m := tmux.NewManager()
+	if got := checkServer(m); got.Status != Warn {
+		t.Errorf("checkServer() without a server = %+v, want status %v", got, Warn)
+	}
+	if output, err := exec.Command("tmux", "new-session", "-d", "-s", "dev").CombinedOutput(); err != nil {
+		t.Fatalf("new-session: %v: %s", err, output)
+	}
+	if got := checkServer(m); got.Status != OK {
+		t.Errorf("checkServer() = %+v, want status %v", got, OK)
+	}
+}
+
+func TestCheckTmuxEnv(t *testing.T) {
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "default")
+	if err := os.WriteFile(socket, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		env  string
+		want Status
+	}{
+		{"outside tmux", "", Warn},
+		{"inside tmux", socket + ",1234,0", OK},
+		// 服务器退出后遗留的 $TMUX
+		{"stale", filepath.Join(dir, "gone") + ",1234,0", Fail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TMUX", tt.env)
+			if got := checkTmuxEnv(); got.Status != tt.want {
+				t.Errorf("checkTmuxEnv() = %+v, want status %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSocket(t *testing.T) {
+	tests := []struct {
+		name    string
+		dirMode os.FileMode // 0 表示不创建 socket
+		want    Status
+	}{
+		{"private", 0o700, OK},
+		{"missing", 0, Warn},
+		{"group readable dir", 0o750, Fail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			t.Setenv("TMUX", "")
+			t.Setenv("TMUX_TMPDIR", tmp)
+			if tt.dirMode != 0 {
+				dir := filepath.Dir(tmux.SocketPath())
+				if err := os.Mkdir(dir, tt.dirMode); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Chmod(dir, tt.dirMode); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(tmux.SocketPath(), nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := checkSocket(); got.Status != tt.want {
+				t.Errorf("checkSocket() = %+v, want status %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTerminal(t *testing.T) {
+	tests := []struct {
+		name                     string
+		env                      map[string]string
+		term, colors, utf8Status Status
+	}{
+		{
+			name: "good terminal",
+			env:  map[string]string{"TERM": "xterm-256color", "LANG": "en_US.UTF-8"},
+			term: OK, colors: OK, utf8Status: OK,
+		},
+		{
+			name: "inside tmux",
+			env:  map[string]string{"TMUX": "/tmp/tmux-0/default,1,0", "TERM": "tmux-256color", "LC_ALL": "zh_CN.utf8"},
+			term: OK, colors: OK, utf8Status: OK,
+		},
+		{
+			name: "wrong TERM inside tmux",
+			env:  map[string]string{"TMUX": "/tmp/tmux-0/default,1,0", "TERM": "xterm-256color", "LANG": "C.UTF-8"},
+			term: Warn, colors: OK, utf8Status: OK,
+		},
+		{
+			name: "truecolor",
+			env:  map[string]string{"TERM": "xterm", "COLORTERM": "truecolor", "LANG": "C.UTF-8"},
+			term: OK, colors: OK, utf8Status: OK,
+		},
+		{
+			name: "basic colors and no UTF-8",
+			env:  map[string]string{"TERM": "xterm", "LANG": "C"},
+			term: OK, colors: Warn, utf8Status: Warn,
+		},
+		{
+			name: "NO_COLOR",
+			env:  map[string]string{"TERM": "xterm", "NO_COLOR": "1", "LANG": "C.UTF-8"},
+			term: OK, colors: OK, utf8Status: OK,
+		},
+		{
+			name: "dumb",
+			env:  map[string]string{"TERM": "dumb"},
+			term: Fail, colors: Warn, utf8Status: Warn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"TMUX", "TERM", "COLORTERM", "NO_COLOR", "LC_ALL", "LC_CTYPE", "LANG"} {
+				t.Setenv(key, tt.env[key])
+			}
+			checks := checkTerminal()
+			if len(checks) != 3 {
+				t.Fatalf("checkTerminal() returned %d checks", len(checks))
+			}
+			want := []Status{tt.term, tt.colors, tt.utf8Status}
+			for i, c := range checks {
+				if c.Status != want[i] {
+					t.Errorf("%s = %+v, want status %v", c.Name, c, want[i])
+				}
+			}
+		})
+	}
+}
+
+const (
+	tmuxBlock  = "# >>> tmx:tmux v4 >>>\nbind t display-popup -E tmx\n# <<< tmx:tmux <<<\n"
+	shellBlock = "# >>> tmx:shell v4 >>>\neval \"$(tmx init bash)\"\n# <<< tmx:shell <<<\n"
+	oldBlock   = "# >>> tmx:shell v3 >>>\neval \"$(tmx init bash)\"\n# <<< tmx:shell <<<\n"
+)
+
+func TestCheckInstall(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmuxConf string
+		bashrc   string
+		binding  string // 运行中 tmux 的 prefix t 绑定
+		loaded   bool   // 当前 shell 是否加载了集成代码
+		want     []Status
+	}{
+		{
+			name:     "installed and loaded",
+			tmuxConf: tmuxBlock,
+			bashrc:   "alias ll='ls -l'\n" + shellBlock,
+			binding:  "bind-key -T prefix t display-popup -E tmx",
+			loaded:   true,
+			want:     []Status{OK, OK, OK, OK},
+		},
+		{
+			name: "nothing installed",
+			want: []Status{Warn, Warn},
+		},
+		{
+			// rc 文件中有配置块，但当前 shell 是在安装之前启动的
+			name:     "shell not reloaded",
+			tmuxConf: tmuxBlock,
+			bashrc:   shellBlock,
+			binding:  "bind-key -T prefix t display-popup -E tmx",
+			want:     []Status{OK, OK, OK, Warn},
+		},
+		{
+			name:     "tmux config not sourced",
+			tmuxConf: tmuxBlock,
+			bashrc:   shellBlock,
+			binding:  "bind-key -T prefix t new-window",
+			loaded:   true,
+			want:     []Status{OK, Warn, OK, OK},
+		},
+		{
+			name:     "outdated shell block",
+			tmuxConf: tmuxBlock,
+			bashrc:   oldBlock,
+			binding:  "bind-key -T prefix t display-popup -E tmx",
+			loaded:   true,
+			want:     []Status{OK, OK, Warn, OK},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("SHELL", "/bin/bash")
+			t.Setenv("XDG_CONFIG_HOME", "")
+			if tt.tmuxConf != "" {
+				writeFile(t, filepath.Join(home, ".tmux.conf"), tt.tmuxConf)
+			}
+			if tt.bashrc != "" {
+				writeFile(t, filepath.Join(home, ".bashrc"), tt.bashrc)
+			}
+			pid := ""
+			if tt.loaded {
+				pid = strconv.Itoa(os.Getppid())
+			}
+			t.Setenv(shell.LoadedEnv, pid)
+
+			keyBinding := func(table, key string) string { return tt.binding }
+			checks := checkInstall(config.Options{Home: home}, keyBinding, true)
+			if len(checks) != len(tt.want) {
+				t.Fatalf("checkInstall() = %+v, want %d checks", checks, len(tt.want))
+			}
+			for i, c := range checks {
+				if c.Status != tt.want[i] {
+					t.Errorf("%s = %+v, want status %v", c.Name, c, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckShellLoaded(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want Status
+	}{
+		{"current shell", strconv.Itoa(os.Getppid()), OK},
+		{"not loaded", "", Warn},
+		// 由其他 shell 导出后继承而来，当前 shell 没有定义函数
+		{"inherited", strconv.Itoa(os.Getppid() + 1), Warn},
+		{"garbage", "yes", Warn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(shell.LoadedEnv, tt.env)
+			if got := checkShellLoaded(config.BlockStatus{Label: "bash", Path: "~/.bashrc"}); got.Status != tt.want {
+				t.Errorf("checkShellLoaded() = %+v, want status %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
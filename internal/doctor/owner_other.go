@@ -0,0 +1,12 @@
+//go:build !unix
+
+package doctor
+
+import (
+	"os"
+)
+
+// fileOwner 在非 Unix 平台上无法获取所有者
+func fileOwner(info os.FileInfo) (int, bool) {
+	return 0, false
+}
@@ -0,0 +1,144 @@
+// Package git 读取会话工作目录的 git 状态，用于会话列表的分支标记
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Timeout 是单次 git status 的超时时间，大仓库或网络文件系统上超时的目录不显示标记
+	Timeout = 2 * time.Second
+	// workers 是同时运行的 git status 数量
+	workers = 8
+)
+
+// Status 是工作目录的 git 状态
+type Status struct {
+	Branch  string // 当前分支，分离 HEAD 时为提交号的前 7 位
+	Ahead   int    // 领先上游的提交数
+	Behind  int    // 落后上游的提交数
+	Changes int    // 未提交的修改，包括未跟踪的文件
+}
+
+// Dirty 返回是否有未提交的修改
+func (s Status) Dirty() bool {
+	return s.Changes > 0
+}
+
+// String 返回紧凑的状态，例如 main* ↑1 ↓2，* 表示有未提交的修改
+func (s Status) String() string {
+	text := s.Branch
+	if s.Dirty() {
+		text += "*"
+	}
+	if s.Ahead > 0 {
+		text += fmt.Sprintf(" ↑%d", s.Ahead)
+	}
+	if s.Behind > 0 {
+		text += fmt.Sprintf(" ↓%d", s.Behind)
+	}
+	return text
+}
+
+// Read 读取 dir 的 git 状态，不是 git 仓库、git 不可用或超时时 ok 为 false
+func Read(dir string) (Status, bool) {
+	if dir == "" {
+		return Status{}, false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "status", "--porcelain=v2", "--branch")
+	// 不在后台刷新索引，避免与用户正在运行的 git 命令争抢 index.lock
+	cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+	output, err := cmd.Output()
+	if err != nil {
+		return Status{}, false
+	}
+	return parseStatus(string(output)), true
+}
+
+// parseStatus 解析 git status --porcelain=v2 --branch 的输出
+func parseStatus(output string) Status {
+	var s Status
+	oid := ""
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.oid "):
+			oid = strings.TrimPrefix(line, "# branch.oid ")
+		case strings.HasPrefix(line, "# branch.head "):
+			s.Branch = strings.TrimPrefix(line, "# branch.head ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			fmt.Sscanf(strings.TrimPrefix(line, "# branch.ab "), "+%d -%d", &s.Ahead, &s.Behind)
+		case line != "" && !strings.HasPrefix(line, "#"):
+			s.Changes++
+		}
+	}
+	if s.Branch == "(detached)" && len(oid) >= 7 {
+		s.Branch = oid[:7]
+	}
+	return s
+}
+
+// Cache 缓存每个目录的 git 状态，可以被多个 goroutine 使用
+type Cache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+type entry struct {
+	status Status
+	ok     bool
+	at     time.Time
+}
+
+// NewCache 创建缓存，状态在 ttl 之后重新读取
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{ttl: ttl, entries: make(map[string]entry)}
+}
+
+// Statuses 返回 dirs 中是 git 仓库的目录的状态，过期或未读取的目录并发重新读取
+func (c *Cache) Statuses(dirs []string) map[string]Status {
+	c.mu.Lock()
+	var stale []string
+	for _, dir := range dirs {
+		if e, ok := c.entries[dir]; (!ok || time.Since(e.at) >= c.ttl) && !slices.Contains(stale, dir) {
+			stale = append(stale, dir)
+		}
+	}
+	c.mu.Unlock()
+
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for _, dir := range stale {
+		wg.Add(1)
+		go func(dir string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			status, ok := Read(dir)
+			c.mu.Lock()
+			c.entries[dir] = entry{status: status, ok: ok, at: time.Now()}
+			c.mu.Unlock()
+		}(dir)
+	}
+	wg.Wait()
+
+	statuses := make(map[string]Status)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, dir := range dirs {
+		if e := c.entries[dir]; e.ok {
+			statuses[dir] = e.status
+		}
+	}
+	return statuses
+}
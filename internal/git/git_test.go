@@ -0,0 +1,79 @@
+package git
+
+import "testing"
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   Status
+		text   string
+	}{
+		{
+			name: "clean",
+			output: `# branch.oid 9e7b6c9d2f1a0b3c4d5e6f708192a3b4c5d6e7f8
+# branch.head main
+# branch.upstream origin/main
+# branch.ab +0 -0
+`,
+			want: Status{Branch: "main"},
+			text: "main",
+		},
+		{
+			name: "ahead and behind",
+			output: `# branch.oid 9e7b6c9d2f1a0b3c4d5e6f708192a3b4c5d6e7f8
+# branch.head feature/login
+# branch.upstream origin/feature/login
+# branch.ab +3 -12
+`,
+			want: Status{Branch: "feature/login", Ahead: 3, Behind: 12},
+			text: "feature/login ↑3 ↓12",
+		},
+		{
+			// 修改、重命名、未合并和未跟踪的文件都计入未提交的修改
+			name: "changes and untracked",
+			output: `# branch.oid 9e7b6c9d2f1a0b3c4d5e6f708192a3b4c5d6e7f8
+# branch.head master
+# branch.upstream origin/master
+# branch.ab +1 -0
+1 .M N... 100644 100644 100644 3f2a1b0c4d5e6f708192a3b4c5d6e7f8091a2b3c 3f2a1b0c4d5e6f708192a3b4c5d6e7f8091a2b3c README.md
+2 R. N... 100644 100644 100644 3f2a1b0c4d5e6f708192a3b4c5d6e7f8091a2b3c 3f2a1b0c4d5e6f708192a3b4c5d6e7f8091a2b3c R100 new.go	old.go
+u UU N... 100644 100644 100644 100644 3f2a1b0c4d5e6f708192a3b4c5d6e7f8091a2b3c 3f2a1b0c4d5e6f708192a3b4c5d6e7f8091a2b3c 3f2a1b0c4d5e6f708192a3b4c5d6e7f8091a2b3c conflict.go
+? notes.txt
+? build/
+`,
+			want: Status{Branch: "master", Ahead: 1, Changes: 5},
+			text: "master* ↑1",
+		},
+		{
+			name: "detached HEAD",
+			output: `# branch.oid 9e7b6c9d2f1a0b3c4d5e6f708192a3b4c5d6e7f8
+# branch.head (detached)
+? scratch.txt
+`,
+			want: Status{Branch: "9e7b6c9", Changes: 1},
+			text: "9e7b6c9*",
+		},
+		{
+			// 还没有提交时 oid 为 (initial)，没有上游时没有 branch.ab
+			name: "no commits yet",
+			output: `# branch.oid (initial)
+# branch.head main
+? main.go
+`,
+			want: Status{Branch: "main", Changes: 1},
+			text: "main*",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseStatus(tt.output)
+			if got != tt.want {
+				t.Errorf("parseStatus = %+v, want %+v", got, tt.want)
+			}
+			if got.String() != tt.text {
+				t.Errorf("String() = %q, want %q", got.String(), tt.text)
+			}
+		})
+	}
+}
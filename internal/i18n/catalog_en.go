@@ -0,0 +1,392 @@
+package i18n
+
+// catalogEN 是英文文案，同时作为缺失文案的回退
+var catalogEN = map[string]string{
+	// 命令行
+	"cli.unknown_arg":              "Unknown argument: %s",
+	"cli.see_help":                 "Run with -h for help",
+	"cli.error":                    "Error: %v",
+	"cli.attach_error":             "Error: cannot attach to session: %v",
+	"cli.settings_error":           "⚠️  Failed to read settings: %v",
+	"cli.version":                  "tmx version %s",
+	"cli.tmux_not_running":         "📝 tmux is not running",
+	"cli.auto_start_hint":          "\n💡 tmx can start tmux and create a default session for you",
+	"cli.auto_start_prompt":        "Start it now? [Y/n]: ",
+	"cli.revive_hint":              "💡 Or press r to revive %s, the most recently archived session (saved %s, %s)",
+	"cli.auto_start_revive_prompt": "Start it now? [Y/n/r]: ",
+	"cli.reviving":                 "\n🚀 Reviving %s...",
+	"cli.starting_tmux":            "\n🚀 Starting tmux...",
+	"cli.create_failed":            "❌ Failed to create tmux session: %v",
+	"cli.start_manually":           "\nYou can start tmux manually:\n  tmux",
+	"cli.start_tmux_first":         "\nPlease start tmux first:\n  tmux\n\nor create a new session:\n  tmux new",
+	"cli.help": `tmx - tmux session manager
+
+Usage:
+  tmx                open the session manager (TUI)
+  tmx --install      install tmux config (key binding + status hint)
+  tmx --uninstall    remove tmux config
+                     both accept --dry-run to print the changes only
+                     --install --all-shells installs quit into every detected shell
+  tmx -h             show help
+  tmx -v             show version
+  tmx theme preview  preview color themes
+  tmx status [name]  print a status-line segment (session rank/count, detached count)
+  tmx doctor         diagnose the environment and suggest fixes
+  tmx init <shell>   print shell integration code (bash/zsh/fish) to eval
+  tmx completion <shell>  print the completion script (bash/zsh/fish)
+  tmx send <target...> -- <command>
+                          send a command to panes, sessions or session patterns
+  tmx grep <regex>        search the scrollback of every pane
+  tmx capture <target>    export scrollback to a file, stdout (-o -) or the clipboard (-c)
+  tmx record <target...>  record pane output to timestamped logs (--cast, --stop)
+  tmx replay <file>       replay a recording
+  tmx watch <target...>   alert when the command running in a pane finishes (--stop)
+  tmx notify test         send a test desktop notification
+  tmx ls                  list sessions with CPU, memory and git status (--format json, --sort cpu|mem, --dirty)
+  tmx ps [target...]      show process trees and listening ports (--port 8080 finds who holds it)
+  tmx hibernate <session...>  save a session's windows, panes and scrollback to disk, then kill it
+  tmx revive [session...]     rebuild hibernated or reaped sessions; without arguments, list archives
+  tmx reap [--dry-run]    archive and kill sessions idle longer than reap.idle_days (default 7)
+  tmx attach <target>     attach to a session, window (dev:1) or pane (dev:1.0)
+                          -r read-only, -d detach other clients, -g grouped session
+
+💡 Usage:
+   tmx               # open the manager from a terminal or inside tmux, pick a session to enter it
+   or run ./tmx --install to bind Ctrl+b t
+
+TUI keys:
+  Enter           attach to the selected session
+  r / D / g       attach read-only / detaching other clients / in a grouped session
+  w               list windows and panes of the session, Enter attaches to one
+  c               list connected clients: detach, switch session, refresh
+  S               send a command to the session (or a pattern such as web-*),
+                  Tab in the prompt switches to sending keys such as C-c
+  /               search the scrollback of every pane, Enter jumps to the match
+  E               export the scrollback to a file or the clipboard
+  i               list idle sessions that tmx reap would kill, x archives and kills one
+  P               pin / unpin a session so it is never reaped
+  H               hibernate the session; hibernated sessions are listed below,
+                  Enter revives one, x deletes its archive
+                  in the window list: n new, e rename, x kill, K/J reorder,
+                  i move to index, m move to session, l link, N renumber,
+                  a monitor activity, M alert after silence
+                  on panes: %/" split, x kill, z zoom, R respawn, ! break out,
+                  > join into window, s synchronize panes, S send a command,
+                  E export scrollback, o start/stop recording
+  n               new session
+  d               detach session
+  x               kill session
+  ↑/↓ or j/k      navigate
+  q/Esc           quit
+
+Leaving a tmux session:
+  Ctrl+b d        detach (session keeps running)
+  quit            detach (requires ./tmx --install first)
+
+Language:
+  Picked from LC_ALL / LC_MESSAGES / LANG, or set
+  "language": "en" or "zh-CN" in ~/.config/tmx/config.json
+`,
+
+	// TUI
+	"tui.title":                    "Tmux Sessions",
+	"tui.title_dirty":              "Tmux Sessions · uncommitted changes",
+	"tui.no_sessions":              "No sessions, press n to create one",
+	"tui.no_dirty_sessions":        "No sessions with uncommitted changes",
+	"tui.hints":                    "[Enter]attach [d]detach [n]new [x]kill [H]hibernate [q]quit",
+	"tui.attach_hints":             "[r]read-only [D]detach others [g]grouped session [w]windows [c]clients [S]send [/]search [E]export [u]usage [s]sort [p]processes [f]uncommitted only [i]idle [P]pin",
+	"tui.windows_title":            "Windows · %s",
+	"tui.panes.one":                "%d pane",
+	"tui.panes.other":              "%d panes",
+	"tui.tree_hints":               "[Enter]attach [r]read-only [D]detach others [g]grouped session [Esc]back [q]quit",
+	"tui.window_hints":             "[n]new [e]rename [x]kill [K/J]move up/down [i]move to index [m]move to session [l]link [N]renumber [a]monitor activity [M]monitor silence",
+	"tui.pane_hints":               "[%/\"]split [x]kill pane [z]zoom [R]respawn [!]break out [>]join into window [s]sync panes [S]send [E]export [o]record [W]watch [p]processes",
+	"tui.send_title":               "Send Command",
+	"tui.send_targets_prompt":      "Targets (session, session:window.pane, wildcards like web-*):",
+	"tui.send_command_prompt":      "Command to send to %s (Tab: send keys instead):",
+	"tui.send_keys_title":          "Send Keys",
+	"tui.send_keys_prompt":         "Keys to send to %s, tmux key names like C-c or Escape :wq Enter (Tab: send a command instead):",
+	"tui.send_keys_preview":        "Keys: %s",
+	"tui.send_preview_title.one":   "Send to %d pane",
+	"tui.send_preview_title.other": "Send to %d panes",
+	"tui.send_hints":               "[Enter]send [Esc]cancel",
+	"tui.send_no_targets":          "No panes match: %s",
+	"tui.send_done.one":            "✓ Sent to %d pane",
+	"tui.send_done.other":          "✓ Sent to %d panes",
+	"tui.search_title":             "Search Scrollback",
+	"tui.search_prompt":            "Regular expression to search for in every pane:",
+	"tui.search_results_title":     "Search · %s",
+	"tui.searching":                "Searching…",
+	"tui.no_matches":               "No matches",
+	"tui.match_count.one":          "%d match",
+	"tui.match_count.other":        "%d matches",
+	"tui.search_hints":             "[Enter]jump to pane [v]open at match in copy mode [/]new search [Esc]back [q]quit",
+	"tui.export_title":             "Export scrollback of %s",
+	"tui.export_file":              "Save to %s",
+	"tui.export_escapes":           "Save with colors (ANSI escapes)",
+	"tui.export_clipboard":         "Copy to the clipboard (OSC 52)",
+	"tui.export_saved":             "✓ Saved to %s",
+	"tui.export_copied.one":        "✓ Copied %d line to the clipboard",
+	"tui.export_copied.other":      "✓ Copied %d lines to the clipboard",
+	"tui.recording":                "[rec]",
+	"tui.record_started":           "● Recording to %s",
+	"tui.record_stopped":           "Recording stopped: %s",
+	"tui.watching":                 "[watching]",
+	"tui.watch_finished":           "[✓ %s %s]",
+	"tui.watch_failed":             "[✗ %s exit %d %s]",
+	"tui.watch_done":               "[done]",
+	"tui.watch_started":            "Watching %s, you will be alerted when it finishes",
+	"tui.sort_cpu":                 "Sorted by CPU",
+	"tui.sort_rss":                 "Sorted by memory",
+	"tui.sort_default":             "Default order",
+	"tui.filter_dirty":             "Showing sessions whose directory has uncommitted changes, press f again to show all",
+	"tui.usage_unavailable":        "Resource usage unavailable: %v",
+	"tui.processes_title":          "Processes · %s",
+	"tui.loading_processes":        "Reading processes…",
+	"tui.processes_hints":          "[Enter]attach to the pane [Esc]back [q]quit · refreshes every 2s",
+	"tui.pinned":                   "[pinned]",
+	"tui.pinned_session":           "✓ %s pinned, it will not be reaped",
+	"tui.stale_title":              "Idle Sessions · idle for %s or longer",
+	"tui.loading_stale":            "Reading sessions…",
+	"tui.no_stale":                 "No idle sessions to reap",
+	"tui.reaping":                  "Archiving %s…",
+	"tui.reaped":                   "✓ %s archived to %s and killed",
+	"tui.stale_hints":              "[x]archive and kill [P]pin [Enter]attach [Esc]back [q]quit",
+	"tui.hibernated_title":         "Hibernated · Enter revives, x deletes the archive",
+	"tui.archive_reaped":           "[reaped]",
+	"tui.hibernating":              "Hibernating %s…",
+	"tui.hibernated":               "✓ %s hibernated",
+	"tui.hibernate_current":        "Cannot hibernate the session tmx is running in",
+	"tui.reviving":                 "Reviving %s…",
+	"tui.revived":                  "✓ %s revived",
+	"tui.archive_removed":          "✓ Deleted the archive of %s",
+	"tui.archive_remove_title":     "Delete the archive of %s? Its scrollback cannot be recovered",
+	"tui.archive_keep":             "Keep the archive",
+	"tui.archive_remove":           "Delete the archive",
+	"tui.alert_bell":               "[bell]",
+	"tui.alert_activity":           "[activity]",
+	"tui.alert_silence":            "[silent]",
+	"tui.monitor_activity":         "[monitor]",
+	"tui.monitor_silence":          "[quiet>%ds]",
+	"tui.silence_title":            "Monitor Silence",
+	"tui.silence_prompt":           "Alert after this many seconds without output (0 to turn off):",
+	"tui.split_title":              "Split Pane",
+	"tui.split_prompt":             "Command to run (empty for the default shell):",
+	"tui.join_pane_title":          "Join pane %s into window",
+	"tui.pane_dead":                "[dead]",
+	"tui.window_sync":              "[sync]",
+	"tui.new_window_title":         "New Window",
+	"tui.rename_window_title":      "Rename Window",
+	"tui.window_name_prompt":       "Window name:",
+	"tui.move_index_title":         "Move Window",
+	"tui.move_index_prompt":        "New index:",
+	"tui.move_window_title":        "Move window %s to session",
+	"tui.link_window_title":        "Link window %s into session",
+	"tui.action_failed":            "Failed: %v",
+	"tui.clients_title":            "Clients",
+	"tui.no_clients":               "No clients attached",
+	"tui.client_tty":               "TTY",
+	"tui.client_user":              "USER",
+	"tui.client_size":              "SIZE",
+	"tui.client_session":           "SESSION",
+	"tui.client_idle":              "IDLE",
+	"tui.client_readonly":          "read-only",
+	"tui.switch_prompt":            "Switch %s to:",
+	"tui.clients_hints":            "[d]detach [s]switch session [r]refresh [Esc]back [q]quit",
+	"tui.tip_detach":               "💡 Tip: inside a session press Ctrl+b d to leave it running",
+	"tui.new_title":                "New Session",
+	"tui.new_prompt":               "Session name:",
+	"tui.input_hints":              "[Enter]confirm [Esc]cancel",
+	"tui.picker_hints":             "[Enter]select [Esc]cancel",
+	"tui.create_failed":            "Failed to create session: %v",
+
+	// 相对时间
+	"time.just_now":          "just now",
+	"time.minutes_ago.one":   "%d minute ago",
+	"time.minutes_ago.other": "%d minutes ago",
+	"time.hours_ago.one":     "%d hour ago",
+	"time.hours_ago.other":   "%d hours ago",
+	"time.days_ago.one":      "%d day ago",
+	"time.days_ago.other":    "%d days ago",
+	"time.date_layout":       "Jan 2, 2006",
+
+	// 配置安装
+	"config.err_home":           "cannot determine home directory: %w",
+	"config.err_read":           "cannot read config file: %w",
+	"config.err_write_file":     "cannot write config file: %w",
+	"config.err_read_settings":  "cannot read %s: %w",
+	"config.err_parse_settings": "cannot parse %s: %w",
+	"config.err_broken_block":   "%s line %d: the tmx block %q has no end marker; add %q where it ends or delete the block, then run again",
+
+	"install.quit_not_in_tmux": "not inside a tmux session",
+	"install.created":          "✓ [%s] Created config file: %s",
+	"install.appended":         "✓ [%s] Added config to: %s",
+	"install.upgraded":         "✓ [%s] Upgraded config: %s",
+	"install.up_to_date":       "✓ [%s] Config already up to date: %s",
+	"install.newer":            "⚠ [%s] %s was written by a newer tmx, skipping",
+	"install.backup":           "  Backed up original to: %s",
+	"install.dry_run":          "\n(dry-run: changes shown above, nothing was written)",
+	"config.err_backup":        "cannot back up config file: %w",
+	"install.reload_tmux":      "\nReload the tmux config with:\n  tmux source-file %s\n\nor restart tmux",
+	"install.failed":           "✗ [%s] %s: %v",
+	"install.reload_shell":     "Reload your shell config with:\n  source %s",
+
+	"uninstall.no_config":       "⚠ [%s] %s not found, nothing to remove",
+	"uninstall.not_found":       "⚠ [%s] no tmx config in %s, nothing to remove",
+	"uninstall.removed":         "✓ [%s] Removed tmx config from %s",
+	"uninstall.failed":          "✗ [%s] %s: %v",
+	"uninstall.status_restored": "✓ Restored status-right in the running tmux server",
+	"uninstall.status_failed":   "⚠️  Failed to restore status-right: %v",
+	"uninstall.reload_shell":    "\nAlso reload your shell config (or open a new terminal)",
+
+	// 主题
+	"theme.usage":   "Usage: tmx theme preview [name...]",
+	"theme.invalid": "⚠️  Invalid theme config, falling back to auto: %v",
+	"theme.current": "Current theme: %s",
+
+	// 弹窗
+	"tui.tip_popup":             "💡 Tip: the popup closes after you pick a session, Esc closes it right away",
+	"install.popup_unsupported": "⚠ Detected tmux %s; display-popup needs 3.2+, the manager will open in a new window instead",
+
+	// 状态栏
+	"status.rank":             "%d/%d",
+	"status.sessions.one":     "%d session",
+	"status.sessions.other":   "%d sessions",
+	"status.unattached.one":   "%d detached",
+	"status.unattached.other": "%d detached",
+
+	// 环境诊断
+	"doctor.title":                "tmx doctor — environment diagnostics",
+	"doctor.all_ok":               "✓ Everything looks good",
+	"doctor.summary":              "%d failed, %d warnings",
+	"doctor.binary":               "tmux binary",
+	"doctor.binary_missing":       "tmux not found in PATH",
+	"doctor.binary_fix":           "Install tmux: apt install tmux / brew install tmux",
+	"doctor.version":              "tmux version",
+	"doctor.version_old":          "%s (older than 3.2, no popup support; Ctrl+b t opens a new window)",
+	"doctor.version_fix":          "Upgrade tmux to 3.2 or later, then rerun tmx --install",
+	"doctor.server":               "tmux server",
+	"doctor.server_down":          "not running or unreachable",
+	"doctor.server_up":            "running (pid %d, socket %s)",
+	"doctor.server_fix":           "Run tmux to start a server",
+	"doctor.tmux_env_unset":       "not set, not inside a tmux session",
+	"doctor.tmux_env_unset_fix":   "Run tmx inside a tmux session, or press Ctrl+b t",
+	"doctor.tmux_env_stale":       "points to missing socket %s",
+	"doctor.tmux_env_stale_fix":   "$TMUX was inherited from a server that exited; run unset TMUX or open a new terminal",
+	"doctor.socket":               "tmux socket",
+	"doctor.socket_missing":       "%s does not exist",
+	"doctor.socket_owner":         "%s is owned by uid %d, not the current user",
+	"doctor.socket_owner_fix":     "Remove the stale socket: rm %s, then restart tmux",
+	"doctor.socket_dir_mode":      "%s has mode %v, tmux requires 0700",
+	"doctor.socket_dir_fix":       "Run chmod 700 %s",
+	"doctor.term_unusable":        "%q cannot run a full-screen UI",
+	"doctor.term_fix":             "Set TERM, e.g. export TERM=xterm-256color",
+	"doctor.term_inside_tmux":     "%s (should be screen-* or tmux-* inside tmux)",
+	"doctor.term_inside_tmux_fix": "Add set -g default-terminal \"tmux-256color\" to your tmux config",
+	"doctor.colors":               "colors",
+	"doctor.colors_disabled":      "disabled by NO_COLOR",
+	"doctor.colors_truecolor":     "truecolor",
+	"doctor.colors_256":           "256 colors",
+	"doctor.colors_basic":         "only basic colors detected",
+	"doctor.colors_fix":           "Use a 256-color terminal and set TERM=xterm-256color",
+	"doctor.utf8_missing":         "locale %q is not UTF-8, borders and icons may be garbled",
+	"doctor.utf8_fix":             "Use a UTF-8 locale, e.g. export LANG=en_US.UTF-8",
+	"doctor.install":              "install status",
+	"doctor.install_block":        "%s config",
+	"doctor.install_missing":      "tmx config not installed in %s",
+	"doctor.install_outdated":     "config block in %s is v%d and outdated",
+	"doctor.install_fix":          "Run tmx --install (add --dry-run to preview)",
+	"doctor.sourced":              "tmux config loaded",
+	"doctor.sourced_ok":           "Ctrl+b t is bound to tmx",
+	"doctor.sourced_missing":      "the running tmux has not loaded the tmx config",
+	"doctor.sourced_fix":          "Run tmux source-file %s",
+	"doctor.shell_loaded":         "%s integration loaded",
+	"doctor.shell_loaded_ok":      "defined in the current shell",
+	"doctor.shell_loaded_missing": "not loaded in the current shell (the tmx functions are undefined)",
+	"doctor.shell_loaded_fix":     "Open a new terminal, or run source %s",
+
+	// shell 集成
+	"init.usage":               "Usage: tmx init <bash|zsh|fish> [--auto-attach] [--alias NAME | --no-alias]\n\nAdd to your shell config:\n  bash/zsh: eval \"$(tmx init bash)\"\n  fish:     tmx init fish | source",
+	"init.detach_comment":      "Detach from the current tmux session and keep it running (same as Ctrl+b d)",
+	"init.loaded_comment":      "Lets tmx doctor check that this shell has loaded the integration",
+	"init.no_override_comment": "Only define quit / detach when no command with that name exists",
+	"init.alias_comment":       "Quick switch: no argument opens tmx, a session name switches or attaches to it",
+	"init.completion_comment":  "Register tmx command completion",
+	"init.auto_attach_comment": "Attach to tmux when the terminal starts (set TMX_NO_AUTO_ATTACH=1 to skip)",
+	"completion.usage":         "Usage: tmx completion <bash|zsh|fish>\n\nLoaded automatically by tmx init; to load only completion:\n  bash: source <(tmx completion bash)\n  zsh:  source <(tmx completion zsh)\n  fish: tmx completion fish | source",
+
+	// 连接
+	"attach.usage": "Usage: tmx attach <session[:window[.pane]]> [-r|--read-only] [-d|--detach-others] [-g|--group]",
+
+	// 发送命令
+	"send.usage":         "Usage: tmx send <target...> [-k|--keys] [-y|--yes] -- <command>\n\nTargets: session, session:window, session:window.pane; session names accept wildcards (web-*)\n  -k  send tmux key names (e.g. C-c) instead of a command followed by Enter\n  -y  skip the confirmation",
+	"send.no_targets":    "No panes match: %s",
+	"send.preview.one":   "Send %[2]q to %[1]d pane:",
+	"send.preview.other": "Send %[2]q to %[1]d panes:",
+	"send.confirm":       "Send? [y/N]: ",
+	"send.failed":        "✗ %v",
+	"send.sent.one":      "✓ Sent to %d pane",
+	"send.sent.other":    "✓ Sent to %d panes",
+
+	// 搜索滚动历史
+	"grep.usage":     "Usage: tmx grep [-i] [-C lines] [--all] <regex>\n  -i, --ignore-case  case-insensitive match\n  -C, --context      lines of context around each match\n  -a, --all          read the full scrollback (default: last %d lines per pane)",
+	"grep.bad_regex": "Invalid regular expression: %v",
+
+	// 导出滚动历史
+	"capture.usage":        "Usage: tmx capture <session[:window[.pane]]> [-e|--escapes] [-c|--clipboard] [-o|--output <file>]\n  -e  keep colors and other ANSI escapes\n  -c  copy to the system clipboard (OSC 52)\n  -o  write to a file instead of the export directory, - for stdout",
+	"capture.saved":        "✓ Saved to %s",
+	"capture.copied.one":   "✓ Copied %d line to the clipboard",
+	"capture.copied.other": "✓ Copied %d lines to the clipboard",
+
+	// 录制和回放
+	"record.usage":    "Usage: tmx record [--cast] [--stop] <target...>\n\nTargets: session, session:window, session:window.pane; session names accept wildcards (web-*)\n  --cast  also write an asciicast v2 file (play with tmx replay or asciinema)\n  --stop  stop recording",
+	"record.started":  "● %s → %s",
+	"record.stopped":  "■ %s stopped: %s",
+	"record.already":  "%s is already recording to %s",
+	"replay.usage":    "Usage: tmx replay <file.log|file.cast>",
+	"replay.empty":    "Nothing recorded in %s",
+	"replay.title":    "Replay · %s",
+	"replay.hints":    "[Space]play/pause [←/→]seek 5s [-/+]speed [g/G]start/end [q]quit",
+	"notify.usage":    "Usage: tmx notify <bell|silence|activity> <session> <window> [name]\n       tmx notify test",
+	"notify.test":     "Notifications are working",
+	"notify.bell":     "Bell in %s %s",
+	"notify.silence":  "%s %s has gone quiet",
+	"notify.activity": "Activity in %s %s",
+
+	// 等待命令结束
+	"watch.usage":    "Usage: tmx watch [--stop] <target...>\n\nAlert when the command running in each pane finishes; without targets, list watched and finished panes\n  --stop  stop watching and clear the finished marker",
+	"watch.started":  "⏳ %s: watching %s",
+	"watch.stopped":  "■ %s: no longer watched",
+	"watch.watching": "watching %s",
+	"watch.finished": "✓ %s %s",
+	"watch.failed":   "✗ %s exit %d %s",
+
+	// 会话列表
+	"ls.usage":         "Usage: tmx ls [--format text|json] [--sort cpu|mem] [--dirty]\n  --dirty  only sessions whose directory has uncommitted git changes",
+	"ls.windows.one":   "%d window",
+	"ls.windows.other": "%d windows",
+	"ls.attached":      "(attached)",
+
+	// 进程
+	"ps.usage":   "Usage: tmx ps [--port <port>] [target...]\n\nShow the process tree of each pane with runtimes and listening ports\n  --port  only show the processes listening on this port, e.g. --port 8080",
+	"ps.no_port": "No pane is listening on port %d",
+
+	// 清理
+	"reap.usage":         "Usage: tmx reap [--dry-run]\n\nArchive the layout and scrollback of idle sessions, then kill them\nSessions idle longer than reap.idle_days that are not attached or pinned are reaped\n  --dry-run  only list the sessions that would be reaped",
+	"reap.none":          "No sessions idle for %s or longer",
+	"reap.idle":          "idle %s",
+	"reap.archived":      "✓ %s → %s",
+	"reap.dry_run.one":   "%d session would be reaped (dry run)",
+	"reap.dry_run.other": "%d sessions would be reaped (dry run)",
+
+	// 休眠与恢复
+	"hibernate.usage":          "Usage: tmx hibernate <session...>\n\nSave the windows, panes, working directories, commands and scrollback of each session, then kill it\nRevive it later with tmx revive <session>",
+	"hibernate.not_found":      "No session named %s",
+	"hibernate.done":           "💤 %s → %s",
+	"revive.none":              "No archived sessions in %s",
+	"revive.done":              "✓ %s revived (%s)",
+	"archive.reason.hibernate": "hibernated",
+	"archive.reason.reap":      "reaped",
+	"archive.revived":          "── restored from an archive saved %s ──",
+	"archive.revived_command":  "── restored from an archive saved %s, this pane was running %s ──",
+}
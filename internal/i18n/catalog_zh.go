@@ -0,0 +1,376 @@
+package i18n
+
+// catalogZH 是简体中文文案
+var catalogZH = map[string]string{
+	// 命令行
+	"cli.unknown_arg":              "未知参数: %s",
+	"cli.see_help":                 "使用 -h 查看帮助",
+	"cli.error":                    "错误: %v",
+	"cli.attach_error":             "错误: 无法连接到会话: %v",
+	"cli.settings_error":           "⚠️  读取配置失败: %v",
+	"cli.version":                  "tmx version %s",
+	"cli.tmux_not_running":         "📝 tmux 未运行",
+	"cli.auto_start_hint":          "\n💡 tmx 可以自动启动 tmux 并创建默认会话",
+	"cli.auto_start_prompt":        "是否自动启动? [Y/n]: ",
+	"cli.revive_hint":              "💡 也可以按 r 恢复最近存档的会话 %s（%s 保存，%s）",
+	"cli.auto_start_revive_prompt": "是否自动启动? [Y/n/r]: ",
+	"cli.reviving":                 "\n🚀 正在恢复 %s...",
+	"cli.starting_tmux":            "\n🚀 正在启动 tmux...",
+	"cli.create_failed":            "❌ 创建 tmux 会话失败: %v",
+	"cli.start_manually":           "\n你可以手动启动 tmux：\n  tmux",
+	"cli.start_tmux_first":         "\n请先启动 tmux：\n  tmux\n\n或者创建新会话：\n  tmux new",
+	"cli.help": `tmx - Tmux 会话管理器
+
+用法:
+  tmx                打开会话管理器（TUI）
+  tmx --install      安装 tmux 配置（快捷键 + 状态栏提示）
+  tmx --uninstall    卸载 tmux 配置
+                     两者都支持 --dry-run，只打印将要进行的修改
+                     --install --all-shells 将 quit 命令安装到所有检测到的 shell
+  tmx -h             显示帮助
+  tmx -v             显示版本
+  tmx theme preview  预览配色主题
+  tmx status [会话]  输出状态栏片段（会话序号/总数、未连接会话数）
+  tmx doctor         诊断运行环境并给出修复建议
+  tmx init <shell>   输出 shell 集成代码（bash/zsh/fish），用于 eval
+  tmx completion <shell>  输出命令补全脚本（bash/zsh/fish）
+  tmx send <目标...> -- <命令>
+                          向窗格、会话或匹配的会话发送命令
+  tmx grep <正则>         搜索所有窗格的滚动历史
+  tmx capture <目标>      导出滚动历史到文件、标准输出（-o -）或剪贴板（-c）
+  tmx record <目标...>    将窗格输出录制为带时间戳的日志（--cast、--stop）
+  tmx replay <文件>       回放录制
+  tmx watch <目标...>     窗格中的命令结束时提醒（--stop）
+  tmx notify test         发送一条测试桌面通知
+  tmx ls                  列出会话的 CPU、内存占用和 git 状态（--format json、--sort cpu|mem、--dirty）
+  tmx ps [目标...]        显示进程树和监听的端口（--port 8080 查找占用端口的窗格）
+  tmx hibernate <会话...>     保存会话的窗口、窗格和滚动历史后关闭会话
+  tmx revive [会话...]        重建休眠或清理的会话，不带参数时列出存档
+  tmx reap [--dry-run]    保存并关闭空闲超过 reap.idle_days（默认 7）天的会话
+  tmx attach <目标>       连接到会话、窗口（dev:1）或窗格（dev:1.0）
+                          -r 只读，-d 断开其他客户端，-g 分组会话
+
+💡 使用方法：
+   tmx               # 在终端或 tmux 中打开管理器，选中会话后进入
+   或运行 ./tmx --install 配置 Ctrl+b t 快捷键
+
+TUI 快捷键:
+  Enter           进入选中的会话
+  r / D / g       只读进入 / 断开其他客户端后进入 / 在分组会话中进入
+  w               查看会话的窗口和窗格，Enter 直接进入
+  c               查看已连接的客户端：断开、切换会话、刷新
+  S               向会话（或 web-* 等通配符匹配的会话）发送命令，
+                  输入时按 Tab 切换为发送 C-c 等按键
+  /               搜索所有窗格的滚动历史，Enter 跳转到匹配的窗格
+  E               导出滚动历史到文件或剪贴板
+  i               查看 tmx reap 会清理的空闲会话，x 保存后关闭
+  P               标记 / 取消标记会话为保留，不会被清理
+  H               休眠会话，休眠的会话列在下方，Enter 恢复，x 删除存档
+                  窗口列表中：n 新建、e 重命名、x 关闭、K/J 调整顺序、
+                  i 移到索引、m 移到会话、l 链接、N 重新编号、
+                  a 监控输出、M 静默提醒
+                  窗格：%/" 拆分、x 关闭、z 最大化、R 重启、! 拆出为窗口、
+                  > 移入窗口、s 同步输入、S 发送命令、E 导出滚动历史、
+                  o 开始/停止录制
+  n               新建会话
+  d               断开会话
+  x               删除会话
+  ↑/↓ 或 j/k      导航
+  q/Esc           退出
+
+退出 tmux 会话:
+  Ctrl+b d        分离会话（保持运行）
+  quit            分离会话（需要先运行 ./tmx --install）
+
+语言:
+  根据 LC_ALL / LC_MESSAGES / LANG 自动选择，
+  也可在 ~/.config/tmx/config.json 中设置 "language": "en" 或 "zh-CN"
+`,
+
+	// TUI
+	"tui.title":                    "Tmux 会话管理",
+	"tui.title_dirty":              "Tmux 会话 · 有未提交的修改",
+	"tui.no_sessions":              "没有会话，按 n 新建会话",
+	"tui.no_dirty_sessions":        "没有未提交修改的会话",
+	"tui.hints":                    "[Enter]进入 [d]断开 [n]新建 [x]删除 [H]休眠 [q]退出",
+	"tui.attach_hints":             "[r]只读 [D]断开其他客户端 [g]分组会话 [w]窗口 [c]客户端 [S]发送 [/]搜索 [E]导出 [u]资源 [s]排序 [p]进程 [f]只看未提交 [i]空闲 [P]保留",
+	"tui.windows_title":            "窗口 · %s",
+	"tui.panes.other":              "%d 个窗格",
+	"tui.tree_hints":               "[Enter]进入 [r]只读 [D]断开其他客户端 [g]分组会话 [Esc]返回 [q]退出",
+	"tui.window_hints":             "[n]新建 [e]重命名 [x]关闭 [K/J]上移/下移 [i]移到索引 [m]移到会话 [l]链接 [N]重新编号 [a]监控输出 [M]静默监控",
+	"tui.pane_hints":               "[%/\"]拆分 [x]关闭窗格 [z]最大化 [R]重启 [!]拆出为窗口 [>]移入窗口 [s]同步输入 [S]发送 [E]导出 [o]录制 [W]等待结束 [p]进程",
+	"tui.send_title":               "发送命令",
+	"tui.send_targets_prompt":      "发送目标（会话、会话:窗口.窗格，支持通配符如 web-*）:",
+	"tui.send_command_prompt":      "发送到 %s 的命令（Tab 切换为发送按键）:",
+	"tui.send_keys_title":          "发送按键",
+	"tui.send_keys_prompt":         "发送到 %s 的按键，使用 tmux 键名，例如 C-c 或 Escape :wq Enter（Tab 切换为发送命令）:",
+	"tui.send_keys_preview":        "按键: %s",
+	"tui.send_preview_title.other": "发送到 %d 个窗格",
+	"tui.send_hints":               "[Enter]发送 [Esc]取消",
+	"tui.send_no_targets":          "没有匹配的窗格: %s",
+	"tui.send_done.other":          "✓ 已发送到 %d 个窗格",
+	"tui.search_title":             "搜索滚动历史",
+	"tui.search_prompt":            "在所有窗格中搜索的正则表达式:",
+	"tui.search_results_title":     "搜索 · %s",
+	"tui.searching":                "正在搜索…",
+	"tui.no_matches":               "没有匹配",
+	"tui.match_count.other":        "%d 处匹配",
+	"tui.search_hints":             "[Enter]跳转到窗格 [v]在复制模式中定位到匹配 [/]重新搜索 [Esc]返回 [q]退出",
+	"tui.export_title":             "导出 %s 的滚动历史",
+	"tui.export_file":              "保存到 %s",
+	"tui.export_escapes":           "保存并保留颜色（ANSI 转义序列）",
+	"tui.export_clipboard":         "复制到剪贴板（OSC 52）",
+	"tui.export_saved":             "✓ 已保存到 %s",
+	"tui.export_copied.other":      "✓ 已复制 %d 行到剪贴板",
+	"tui.recording":                "[录制中]",
+	"tui.record_started":           "● 正在录制到 %s",
+	"tui.record_stopped":           "已停止录制: %s",
+	"tui.watching":                 "[等待中]",
+	"tui.watch_finished":           "[✓ %s %s]",
+	"tui.watch_failed":             "[✗ %s 退出码 %d %s]",
+	"tui.watch_done":               "[已结束]",
+	"tui.watch_started":            "正在等待 %s，结束时会提醒",
+	"tui.sort_cpu":                 "按 CPU 排序",
+	"tui.sort_rss":                 "按内存排序",
+	"tui.sort_default":             "默认顺序",
+	"tui.filter_dirty":             "只显示工作目录有未提交修改的会话，再按 f 显示全部",
+	"tui.usage_unavailable":        "无法读取资源占用: %v",
+	"tui.processes_title":          "进程 · %s",
+	"tui.loading_processes":        "正在读取进程…",
+	"tui.processes_hints":          "[Enter]进入窗格 [Esc]返回 [q]退出 · 每 2 秒刷新",
+	"tui.pinned":                   "[保留]",
+	"tui.pinned_session":           "✓ 已将 %s 标记为保留，不会被清理",
+	"tui.stale_title":              "空闲会话 · 空闲 %s 以上",
+	"tui.loading_stale":            "正在读取会话…",
+	"tui.no_stale":                 "没有需要清理的空闲会话",
+	"tui.reaping":                  "正在保存 %s…",
+	"tui.reaped":                   "✓ %s 已保存到 %s 并关闭",
+	"tui.stale_hints":              "[x]保存并关闭 [P]保留 [Enter]进入 [Esc]返回 [q]退出",
+	"tui.hibernated_title":         "休眠的会话 · Enter 恢复，x 删除存档",
+	"tui.archive_reaped":           "[已清理]",
+	"tui.hibernating":              "正在休眠 %s…",
+	"tui.hibernated":               "✓ %s 已休眠",
+	"tui.hibernate_current":        "不能休眠运行 tmx 的当前会话",
+	"tui.reviving":                 "正在恢复 %s…",
+	"tui.revived":                  "✓ %s 已恢复",
+	"tui.archive_removed":          "✓ 已删除 %s 的存档",
+	"tui.archive_remove_title":     "删除 %s 的存档？其中的滚动历史删除后无法找回",
+	"tui.archive_keep":             "保留存档",
+	"tui.archive_remove":           "删除存档",
+	"tui.alert_bell":               "[响铃]",
+	"tui.alert_activity":           "[有输出]",
+	"tui.alert_silence":            "[静默]",
+	"tui.monitor_activity":         "[监控输出]",
+	"tui.monitor_silence":          "[静默>%d秒]",
+	"tui.silence_title":            "静默监控",
+	"tui.silence_prompt":           "多少秒没有输出时提醒（0 表示关闭）:",
+	"tui.split_title":              "拆分窗格",
+	"tui.split_prompt":             "要运行的命令（留空运行默认 shell）:",
+	"tui.join_pane_title":          "将窗格 %s 移入窗口",
+	"tui.pane_dead":                "[已退出]",
+	"tui.window_sync":              "[同步]",
+	"tui.new_window_title":         "新建窗口",
+	"tui.rename_window_title":      "重命名窗口",
+	"tui.window_name_prompt":       "窗口名称:",
+	"tui.move_index_title":         "移动窗口",
+	"tui.move_index_prompt":        "新的索引:",
+	"tui.move_window_title":        "将窗口 %s 移到会话",
+	"tui.link_window_title":        "将窗口 %s 链接到会话",
+	"tui.action_failed":            "操作失败: %v",
+	"tui.clients_title":            "客户端",
+	"tui.no_clients":               "没有已连接的客户端",
+	"tui.client_tty":               "终端",
+	"tui.client_user":              "用户",
+	"tui.client_size":              "尺寸",
+	"tui.client_session":           "会话",
+	"tui.client_idle":              "空闲",
+	"tui.client_readonly":          "只读",
+	"tui.switch_prompt":            "将 %s 切换到：",
+	"tui.clients_hints":            "[d]断开 [s]切换会话 [r]刷新 [Esc]返回 [q]退出",
+	"tui.tip_detach":               "💡 提示：进入会话后按 Ctrl+b d 可退出但保持会话运行",
+	"tui.new_title":                "新建会话",
+	"tui.new_prompt":               "请输入会话名称:",
+	"tui.input_hints":              "[Enter]确认 [Esc]取消",
+	"tui.picker_hints":             "[Enter]选择 [Esc]取消",
+	"tui.create_failed":            "创建会话失败: %v",
+
+	// 相对时间
+	"time.just_now":          "刚刚",
+	"time.minutes_ago.other": "%d分钟前",
+	"time.hours_ago.other":   "%d小时前",
+	"time.days_ago.other":    "%d天前",
+	"time.date_layout":       "2006-01-02",
+
+	// 配置安装
+	"config.err_home":           "无法获取用户目录: %w",
+	"config.err_read":           "无法读取配置文件: %w",
+	"config.err_write_file":     "无法写入配置文件: %w",
+	"config.err_read_settings":  "无法读取 %s: %w",
+	"config.err_parse_settings": "无法解析 %s: %w",
+	"config.err_broken_block":   "%s 第 %d 行：tmx 配置块 %q 缺少结束标记，请在配置块末尾补上 %q 或删除整个配置块后重试",
+
+	"install.quit_not_in_tmux": "不在 tmux 会话中",
+	"install.created":          "✓ [%s] 已创建配置文件: %s",
+	"install.appended":         "✓ [%s] 已添加配置到: %s",
+	"install.upgraded":         "✓ [%s] 已升级配置: %s",
+	"install.up_to_date":       "✓ [%s] 配置已是最新: %s",
+	"install.newer":            "⚠ [%s] %s 中的配置由更新版本的 tmx 写入，跳过",
+	"install.backup":           "  已备份原文件: %s",
+	"install.dry_run":          "\n（dry-run：以上为将要进行的修改，未写入任何文件）",
+	"config.err_backup":        "无法备份配置文件: %w",
+	"install.reload_tmux":      "\n请运行以下命令重新加载 tmux 配置：\n  tmux source-file %s\n\n或重启 tmux",
+	"install.failed":           "✗ [%s] %s: %v",
+	"install.reload_shell":     "重新加载 shell 配置：\n  source %s",
+
+	"uninstall.no_config":       "⚠ [%s] %s 不存在，无需卸载",
+	"uninstall.not_found":       "⚠ [%s] %s 中未找到 tmx 配置，无需卸载",
+	"uninstall.removed":         "✓ [%s] 已从 %s 移除 tmx 配置",
+	"uninstall.failed":          "✗ [%s] %s: %v",
+	"uninstall.status_restored": "✓ 已恢复运行中 tmux 的 status-right",
+	"uninstall.status_failed":   "⚠️  恢复 status-right 失败: %v",
+	"uninstall.reload_shell":    "\n并重新加载 shell 配置（或重新打开终端）",
+
+	// 主题
+	"theme.usage":   "用法: tmx theme preview [主题名...]",
+	"theme.invalid": "⚠️  主题配置无效，使用自动主题: %v",
+	"theme.current": "当前主题: %s",
+
+	// 弹窗
+	"tui.tip_popup":             "💡 提示：选择会话后弹窗自动关闭，按 Esc 直接关闭",
+	"install.popup_unsupported": "⚠ 检测到 tmux %s，display-popup 需要 3.2 以上版本，改为在新窗口中打开管理器",
+
+	// 状态栏
+	"status.rank":             "%d/%d",
+	"status.sessions.other":   "%d 个会话",
+	"status.unattached.other": "%d 个未连接",
+
+	// 环境诊断
+	"doctor.title":                "tmx doctor — 环境诊断",
+	"doctor.all_ok":               "✓ 一切正常",
+	"doctor.summary":              "%d 项失败，%d 项警告",
+	"doctor.binary":               "tmux 可执行文件",
+	"doctor.binary_missing":       "PATH 中找不到 tmux",
+	"doctor.binary_fix":           "安装 tmux：apt install tmux / brew install tmux",
+	"doctor.version":              "tmux 版本",
+	"doctor.version_old":          "%s（低于 3.2，不支持弹窗，Ctrl+b t 将在新窗口中打开）",
+	"doctor.version_fix":          "升级 tmux 到 3.2 或更高版本后重新运行 tmx --install",
+	"doctor.server":               "tmux 服务器",
+	"doctor.server_down":          "未运行或无法连接",
+	"doctor.server_up":            "运行中（pid %d，socket %s）",
+	"doctor.server_fix":           "运行 tmux 启动服务器",
+	"doctor.tmux_env_unset":       "未设置，当前不在 tmux 会话中",
+	"doctor.tmux_env_unset_fix":   "在 tmux 会话中运行 tmx，或按 Ctrl+b t 打开",
+	"doctor.tmux_env_stale":       "指向不存在的 socket %s",
+	"doctor.tmux_env_stale_fix":   "$TMUX 是从已退出的服务器继承的，运行 unset TMUX 或打开新终端",
+	"doctor.socket":               "tmux socket",
+	"doctor.socket_missing":       "%s 不存在",
+	"doctor.socket_owner":         "%s 的所有者是 uid %d，不是当前用户",
+	"doctor.socket_owner_fix":     "删除失效的 socket：rm %s，然后重新启动 tmux",
+	"doctor.socket_dir_mode":      "%s 的权限为 %v，tmux 要求 0700",
+	"doctor.socket_dir_fix":       "运行 chmod 700 %s",
+	"doctor.term_unusable":        "%q 不支持全屏界面",
+	"doctor.term_fix":             "设置 TERM，例如 export TERM=xterm-256color",
+	"doctor.term_inside_tmux":     "%s（tmux 内应为 screen-* 或 tmux-*）",
+	"doctor.term_inside_tmux_fix": "在 tmux 配置中添加 set -g default-terminal \"tmux-256color\"",
+	"doctor.colors":               "颜色",
+	"doctor.colors_disabled":      "已通过 NO_COLOR 关闭",
+	"doctor.colors_truecolor":     "真彩色",
+	"doctor.colors_256":           "256 色",
+	"doctor.colors_basic":         "只检测到基本颜色",
+	"doctor.colors_fix":           "使用支持 256 色的终端，并设置 TERM=xterm-256color",
+	"doctor.utf8_missing":         "locale %q 不是 UTF-8，边框和图标可能显示为乱码",
+	"doctor.utf8_fix":             "设置 UTF-8 locale，例如 export LANG=en_US.UTF-8",
+	"doctor.install":              "安装状态",
+	"doctor.install_block":        "%s 配置",
+	"doctor.install_missing":      "%s 中未安装 tmx 配置",
+	"doctor.install_outdated":     "%s 中的配置块版本为 v%d，已过期",
+	"doctor.install_fix":          "运行 tmx --install（可先加 --dry-run 预览）",
+	"doctor.sourced":              "tmux 已加载配置",
+	"doctor.sourced_ok":           "Ctrl+b t 已绑定到 tmx",
+	"doctor.sourced_missing":      "运行中的 tmux 尚未加载 tmx 配置",
+	"doctor.sourced_fix":          "运行 tmux source-file %s",
+	"doctor.shell_loaded":         "%s 集成已加载",
+	"doctor.shell_loaded_ok":      "已在当前 shell 中定义",
+	"doctor.shell_loaded_missing": "当前 shell 未加载集成（tmx 的函数未定义）",
+	"doctor.shell_loaded_fix":     "打开新终端，或运行 source %s",
+
+	// shell 集成
+	"init.usage":               "用法: tmx init <bash|zsh|fish> [--auto-attach] [--alias 名称 | --no-alias]\n\n在 shell 配置中加入：\n  bash/zsh: eval \"$(tmx init bash)\"\n  fish:     tmx init fish | source",
+	"init.detach_comment":      "分离当前 tmux 会话但保持运行（相当于 Ctrl+b d）",
+	"init.loaded_comment":      "供 tmx doctor 检查当前 shell 是否已加载集成",
+	"init.no_override_comment": "只在没有同名命令时定义 quit / detach，避免覆盖已有命令",
+	"init.alias_comment":       "快速切换：不带参数打开 tmx，带会话名时直接切换或连接",
+	"init.completion_comment":  "注册 tmx 命令补全",
+	"init.auto_attach_comment": "终端启动时自动连接 tmux（设置 TMX_NO_AUTO_ATTACH=1 可跳过）",
+	"completion.usage":         "用法: tmx completion <bash|zsh|fish>\n\ntmx init 已自动加载补全；只需要补全时：\n  bash: source <(tmx completion bash)\n  zsh:  source <(tmx completion zsh)\n  fish: tmx completion fish | source",
+
+	// 连接
+	"attach.usage": "用法: tmx attach <会话[:窗口[.窗格]]> [-r|--read-only] [-d|--detach-others] [-g|--group]",
+
+	// 发送命令
+	"send.usage":         "用法: tmx send <目标...> [-k|--keys] [-y|--yes] -- <命令>\n\n目标：会话、会话:窗口、会话:窗口.窗格，会话名支持通配符（web-*）\n  -k  按 tmux 按键名发送（例如 C-c），而不是输入命令并回车\n  -y  跳过确认",
+	"send.no_targets":    "没有匹配的窗格: %s",
+	"send.preview.other": "将 %[2]q 发送到 %[1]d 个窗格：",
+	"send.confirm":       "确认发送? [y/N]: ",
+	"send.failed":        "✗ %v",
+	"send.sent.other":    "✓ 已发送到 %d 个窗格",
+
+	// 搜索滚动历史
+	"grep.usage":     "用法: tmx grep [-i] [-C 行数] [--all] <正则>\n  -i, --ignore-case  忽略大小写\n  -C, --context      显示匹配行前后的行数\n  -a, --all          读取全部滚动历史（默认每个窗格读取最近 %d 行）",
+	"grep.bad_regex": "无效的正则表达式: %v",
+
+	// 导出滚动历史
+	"capture.usage":        "用法: tmx capture <会话[:窗口[.窗格]]> [-e|--escapes] [-c|--clipboard] [-o|--output <文件>]\n  -e  保留颜色等 ANSI 转义序列\n  -c  复制到系统剪贴板（OSC 52）\n  -o  写入指定文件而不是导出目录，- 表示标准输出",
+	"capture.saved":        "✓ 已保存到 %s",
+	"capture.copied.other": "✓ 已复制 %d 行到剪贴板",
+
+	// 录制和回放
+	"record.usage":    "用法: tmx record [--cast] [--stop] <目标...>\n\n目标: 会话、会话:窗口、会话:窗口.窗格，会话名支持通配符（web-*）\n  --cast  同时写入 asciicast v2 文件（可用 tmx replay 或 asciinema 播放）\n  --stop  停止录制",
+	"record.started":  "● %s → %s",
+	"record.stopped":  "■ %s 已停止: %s",
+	"record.already":  "%s 已经在录制到 %s",
+	"replay.usage":    "用法: tmx replay <文件.log|文件.cast>",
+	"replay.empty":    "%s 中没有录制内容",
+	"replay.title":    "回放 · %s",
+	"replay.hints":    "[空格]播放/暂停 [←/→]快退/快进 5 秒 [-/+]速度 [g/G]开头/结尾 [q]退出",
+	"notify.usage":    "用法: tmx notify <bell|silence|activity> <会话> <窗口> [窗口名]\n      tmx notify test",
+	"notify.test":     "通知已可以正常发送",
+	"notify.bell":     "%s %s 响铃",
+	"notify.silence":  "%s %s 已经没有输出",
+	"notify.activity": "%s %s 有新的输出",
+
+	// 等待命令结束
+	"watch.usage":    "用法: tmx watch [--stop] <目标...>\n\n在窗格中的命令结束时提醒；不带目标时列出正在等待和已经结束的窗格\n  --stop  取消等待并清除结束标记",
+	"watch.started":  "⏳ %s: 正在等待 %s",
+	"watch.stopped":  "■ %s: 已取消等待",
+	"watch.watching": "等待 %s",
+	"watch.finished": "✓ %s %s",
+	"watch.failed":   "✗ %s 退出码 %d %s",
+
+	// 会话列表
+	"ls.usage":         "用法: tmx ls [--format text|json] [--sort cpu|mem] [--dirty]\n  --dirty  只列出工作目录有未提交 git 修改的会话",
+	"ls.windows.other": "%d 个窗口",
+	"ls.attached":      "（已连接）",
+
+	// 进程
+	"ps.usage":   "用法: tmx ps [--port <端口>] [目标...]\n\n显示每个窗格的进程树、运行时长和监听的端口\n  --port  只显示监听该端口的进程，例如 --port 8080",
+	"ps.no_port": "没有窗格在监听端口 %d",
+
+	// 清理
+	"reap.usage":         "用法: tmx reap [--dry-run]\n\n保存空闲会话的布局和滚动历史，然后关闭会话\n空闲超过 reap.idle_days 天、没有连接且没有标记保留的会话会被清理\n  --dry-run  只列出会被清理的会话",
+	"reap.none":          "没有空闲 %s 以上的会话",
+	"reap.idle":          "空闲 %s",
+	"reap.archived":      "✓ %s → %s",
+	"reap.dry_run.other": "将清理 %d 个会话（预演）",
+
+	// 休眠与恢复
+	"hibernate.usage":          "用法: tmx hibernate <会话...>\n\n保存每个会话的窗口、窗格、工作目录、命令和滚动历史，然后关闭会话\n之后用 tmx revive <会话> 恢复",
+	"hibernate.not_found":      "没有名为 %s 的会话",
+	"hibernate.done":           "💤 %s → %s",
+	"revive.none":              "%s 中没有存档的会话",
+	"revive.done":              "✓ %s 已恢复（%s）",
+	"archive.reason.hibernate": "休眠",
+	"archive.reason.reap":      "已清理",
+	"archive.revived":          "── 从 %s 保存的存档恢复 ──",
+	"archive.revived_command":  "── 从 %s 保存的存档恢复，此窗格原来运行 %s ──",
+}
@@ -0,0 +1,111 @@
+// Package i18n 提供 tmx 界面文案的多语言目录
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 支持的语言
+const (
+	English = "en"
+	Chinese = "zh-CN"
+)
+
+// catalogs 保存每种语言的文案目录
+var catalogs = map[string]map[string]string{
+	English: catalogEN,
+	Chinese: catalogZH,
+}
+
+// current 是当前使用的语言，默认中文
+var current = Chinese
+
+// Init 根据配置和环境变量选择语言
+func Init(configured string) {
+	SetLocale(Detect(configured))
+}
+
+// Detect 按 配置 > LC_ALL > LC_MESSAGES > LANG 的顺序确定语言，都无法识别时使用中文
+func Detect(configured string) string {
+	candidates := []string{
+		configured,
+		os.Getenv("LC_ALL"),
+		os.Getenv("LC_MESSAGES"),
+		os.Getenv("LANG"),
+	}
+	for _, c := range candidates {
+		if locale := Normalize(c); locale != "" {
+			return locale
+		}
+	}
+	return Chinese
+}
+
+// Normalize 将 zh_CN.UTF-8、en_US 之类的 locale 映射到支持的语言
+// 无法识别（包括 C/POSIX）时返回空字符串
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	switch {
+	case locale == "", locale == "c", locale == "posix":
+		return ""
+	case strings.HasPrefix(locale, "zh"):
+		return Chinese
+	case strings.HasPrefix(locale, "en"):
+		return English
+	}
+	return ""
+}
+
+// SetLocale 设置当前语言，未知语言回退到中文
+func SetLocale(locale string) {
+	if _, ok := catalogs[locale]; ok {
+		current = locale
+		return
+	}
+	current = Chinese
+}
+
+// Locale 返回当前语言
+func Locale() string {
+	return current
+}
+
+// T 返回 key 对应的文案，有参数时按 fmt.Sprintf 格式化
+// 当前语言缺失时回退到英文，仍缺失则返回 key 本身
+func T(key string, args ...any) string {
+	msg, ok := catalogs[current][key]
+	if !ok {
+		if msg, ok = catalogEN[key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// N 返回带复数形式的文案，n 作为第一个格式化参数
+// 文案目录中使用 key.one / key.other 两种形式
+func N(key string, n int, args ...any) string {
+	form := ".other"
+	if pluralOne(current, n) {
+		form = ".one"
+	}
+	return T(key+form, append([]any{n}, args...)...)
+}
+
+// pluralOne 判断 n 在该语言中是否使用单数形式
+func pluralOne(locale string, n int) bool {
+	switch locale {
+	case Chinese:
+		// 中文没有单复数变化
+		return false
+	}
+	return n == 1
+}
@@ -0,0 +1,159 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// useLocale 在测试期间切换语言，结束后恢复
+func useLocale(t *testing.T, locale string) {
+	t.Helper()
+	saved := current
+	t.Cleanup(func() { current = saved })
+	SetLocale(locale)
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"zh_CN.UTF-8", Chinese},
+		{"zh_TW.Big5", Chinese},
+		{"zh-CN", Chinese},
+		{"ZH_cn.utf8", Chinese},
+		{"en_US.UTF-8", English},
+		{"en_GB@euro", English},
+		{"en", English},
+		{" en_US ", English},
+		{"C", ""},
+		{"C.UTF-8", ""},
+		{"POSIX", ""},
+		{"de_DE.UTF-8", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.locale); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		lcAll      string
+		lcMessages string
+		lang       string
+		want       string
+	}{
+		{name: "nothing set", want: Chinese},
+		{name: "LANG", lang: "en_US.UTF-8", want: English},
+		{name: "LANG chinese", lang: "zh_CN.UTF-8", want: Chinese},
+		{name: "LC_ALL over LANG", lcAll: "en_US.UTF-8", lang: "zh_CN.UTF-8", want: English},
+		{name: "LC_MESSAGES over LANG", lcMessages: "zh_CN.UTF-8", lang: "en_US.UTF-8", want: Chinese},
+		{name: "LC_ALL over LC_MESSAGES", lcAll: "zh_CN.UTF-8", lcMessages: "en_US.UTF-8", want: Chinese},
+		// C/POSIX 不表示语言，继续看后面的变量
+		{name: "LC_ALL=C", lcAll: "C", lang: "en_US.UTF-8", want: English},
+		{name: "POSIX only", lcAll: "POSIX", lang: "C.UTF-8", want: Chinese},
+		{name: "unsupported language", lang: "fr_FR.UTF-8", want: Chinese},
+		{name: "config over env", configured: "en", lcAll: "zh_CN.UTF-8", want: English},
+		{name: "unrecognized config", configured: "auto", lang: "en_US.UTF-8", want: English},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", tt.lcMessages)
+			t.Setenv("LANG", tt.lang)
+			if got := Detect(tt.configured); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.configured, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLocaleUnknown(t *testing.T) {
+	useLocale(t, English)
+	SetLocale("fr")
+	if got := Locale(); got != Chinese {
+		t.Errorf("Locale() after SetLocale(fr) = %q, want %q", got, Chinese)
+	}
+}
+
+func TestN(t *testing.T) {
+	tests := []struct {
+		locale string
+		n      int
+		want   string
+	}{
+		{English, 0, "0 panes"},
+		{English, 1, "1 pane"},
+		{English, 2, "2 panes"},
+		{Chinese, 1, "1 个窗格"},
+		{Chinese, 2, "2 个窗格"},
+	}
+	for _, tt := range tests {
+		useLocale(t, tt.locale)
+		if got := N("tui.panes", tt.n); got != tt.want {
+			t.Errorf("%s: N(tui.panes, %d) = %q, want %q", tt.locale, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestTFallback(t *testing.T) {
+	useLocale(t, Chinese)
+	// 只有英文的文案
+	catalogEN["test.only_en"] = "only %s"
+	t.Cleanup(func() { delete(catalogEN, "test.only_en") })
+
+	if got := T("test.only_en", "english"); got != "only english" {
+		t.Errorf("missing Chinese message = %q, want the English one", got)
+	}
+	if got := T("test.missing"); got != "test.missing" {
+		t.Errorf("missing message = %q, want the key", got)
+	}
+	if got := T("tui.panes.other", 3); got != "3 个窗格" {
+		t.Errorf("T(tui.panes.other, 3) = %q", got)
+	}
+}
+
+// TestCatalogsMatch 检查两种语言的文案目录包含相同的 key，且格式化参数一致
+// 中文没有单复数变化，只需要 .other 形式
+func TestCatalogsMatch(t *testing.T) {
+	for key, en := range catalogEN {
+		if strings.HasSuffix(key, ".one") {
+			if _, ok := catalogEN[strings.TrimSuffix(key, ".one")+".other"]; !ok {
+				t.Errorf("en: %s has no .other form", key)
+			}
+			continue
+		}
+		zh, ok := catalogZH[key]
+		if !ok {
+			t.Errorf("zh-CN: missing %s", key)
+			continue
+		}
+		if got, want := verbs(zh), verbs(en); !slices.Equal(got, want) {
+			t.Errorf("%s: zh-CN verbs %v, en verbs %v", key, got, want)
+		}
+	}
+	for key := range catalogZH {
+		if strings.HasSuffix(key, ".one") {
+			t.Errorf("zh-CN: %s is never used", key)
+		}
+		if _, ok := catalogEN[key]; !ok {
+			t.Errorf("en: missing %s", key)
+		}
+	}
+}
+
+var verbPattern = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+
+// verbs 返回文案中的格式化动词，按字母排序（不同语言的参数顺序可以不同）
+func verbs(msg string) []string {
+	found := verbPattern.FindAllString(msg, -1)
+	slices.Sort(found)
+	return found
+}
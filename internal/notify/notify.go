@@ -0,0 +1,44 @@
+// Package notify 发送桌面通知
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Send 发送一条桌面通知
+// command 不为空时由 sh -c 执行，$1 为标题，$2 为内容，例如：
+//
+//	notify-send -u critical "$1" "$2"
+//
+// command 为空时依次尝试 osascript（macOS）和 notify-send，都不可用时用 tmux 状态栏消息代替
+func Send(command, title, message string) error {
+	var cmd *exec.Cmd
+	switch {
+	case command != "":
+		cmd = exec.Command("sh", "-c", command, "tmx-notify", title, message)
+	case runtime.GOOS == "darwin":
+		// 通过 argv 传递内容，避免处理 AppleScript 的引号转义
+		cmd = exec.Command("osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title, message)
+	case hasCommand("notify-send"):
+		cmd = exec.Command("notify-send", title, message)
+	default:
+		cmd = exec.Command("tmux", "display-message", "-d", "5000", title+": "+message)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notifier failed: %s", strings.TrimSpace(string(output)+" "+err.Error()))
+	}
+	return nil
+}
+
+func hasCommand(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
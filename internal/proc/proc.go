@@ -0,0 +1,111 @@
+// Package proc 从 /proc 读取进程树，统计窗格中进程的 CPU 和内存占用
+package proc
+
+import (
+	"fmt"
+	"time"
+)
+
+// Process 是快照中的一个进程
+type Process struct {
+	PID     int
+	PPID    int
+	Command string        // 进程名（/proc/<pid>/stat 中的 comm）
+	CPUTime time.Duration // 用户态和内核态 CPU 时间之和
+	RSS     uint64        // 常驻内存，字节
+	Started time.Time     // 启动时间
+}
+
+// Snapshot 是某一时刻所有进程的快照
+type Snapshot struct {
+	At        time.Time
+	Processes map[int]Process
+	children  map[int][]int
+}
+
+func newSnapshot(processes map[int]Process) *Snapshot {
+	s := &Snapshot{At: time.Now(), Processes: processes, children: make(map[int][]int)}
+	for pid, p := range processes {
+		s.children[p.PPID] = append(s.children[p.PPID], pid)
+	}
+	return s
+}
+
+// Descendants 返回 pid 及其所有子孙进程
+func (s *Snapshot) Descendants(pid int) []int {
+	if _, ok := s.Processes[pid]; !ok {
+		return nil
+	}
+	pids := []int{pid}
+	for i := 0; i < len(pids); i++ {
+		pids = append(pids, s.children[pids[i]]...)
+	}
+	return pids
+}
+
+// Usage 是一组进程的资源占用
+type Usage struct {
+	CPU float64 // 占单个 CPU 的百分比，多核时可以超过 100
+	RSS uint64  // 常驻内存，字节
+}
+
+// Add 返回两者之和
+func (u Usage) Add(other Usage) Usage {
+	return Usage{CPU: u.CPU + other.CPU, RSS: u.RSS + other.RSS}
+}
+
+// Tree 统计 pid 及其子孙进程的资源占用
+// CPU 按 prev 到 s 之间的 CPU 时间计算，prev 为空时为 0
+func (s *Snapshot) Tree(pid int, prev *Snapshot) Usage {
+	var u Usage
+	var cpu time.Duration
+	for _, p := range s.Descendants(pid) {
+		proc := s.Processes[p]
+		u.RSS += proc.RSS
+		if prev == nil {
+			continue
+		}
+		// 两次快照之间启动的进程计入全部 CPU 时间
+		before := prev.Processes[p]
+		if before.CPUTime <= proc.CPUTime {
+			cpu += proc.CPUTime - before.CPUTime
+		}
+	}
+	if prev != nil {
+		if elapsed := s.At.Sub(prev.At); elapsed > 0 {
+			u.CPU = float64(cpu) / float64(elapsed) * 100
+		}
+	}
+	return u
+}
+
+// Sample 间隔 interval 取两次快照，用于计算这段时间内的 CPU 占用
+func Sample(interval time.Duration) (cur, prev *Snapshot, err error) {
+	prev, err = Take()
+	if err != nil {
+		return nil, nil, err
+	}
+	time.Sleep(interval)
+	cur, err = Take()
+	if err != nil {
+		return nil, nil, err
+	}
+	return cur, prev, nil
+}
+
+// FormatCPU 格式化 CPU 占用，例如 12.5%
+func FormatCPU(cpu float64) string {
+	return fmt.Sprintf("%.1f%%", cpu)
+}
+
+// FormatRSS 格式化内存占用，例如 512K、120M、1.5G
+func FormatRSS(rss uint64) string {
+	const k = 1024
+	switch {
+	case rss < k*k:
+		return fmt.Sprintf("%dK", rss/k)
+	case rss < k*k*k:
+		return fmt.Sprintf("%dM", rss/(k*k))
+	}
+	return fmt.Sprintf("%.1fG", float64(rss)/(k*k*k))
+}
@@ -0,0 +1,176 @@
+//go:build linux
+
+package proc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockTicks 是 /proc/<pid>/stat 中 CPU 时间的单位（USER_HZ），Linux 上固定为 100
+const clockTicks = 100
+
+// Take 读取 /proc 中所有进程
+func Take() (*Snapshot, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read /proc: %w", err)
+	}
+	pageSize := uint64(os.Getpagesize())
+	boot := bootTime()
+	processes := make(map[int]Process)
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		if p, ok := readStat(pid, pageSize, boot); ok {
+			processes[pid] = p
+		}
+	}
+	return newSnapshot(processes), nil
+}
+
+// bootTime 返回系统启动时间（/proc/stat 中的 btime）
+func bootTime() time.Time {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return time.Time{}
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, ok := strings.CutPrefix(line, "btime "); ok {
+			sec, _ := strconv.ParseInt(value, 10, 64)
+			return time.Unix(sec, 0)
+		}
+	}
+	return time.Time{}
+}
+
+// readStat 读取 /proc/<pid>/stat，进程可能在读取前退出
+func readStat(pid int, pageSize uint64, boot time.Time) (Process, bool) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return Process{}, false
+	}
+	return parseStat(pid, string(data), pageSize, boot)
+}
+
+// parseStat 解析 /proc/<pid>/stat 的内容
+// 进程名在括号中，可能包含空格和括号，以最后一个右括号为准
+func parseStat(pid int, s string, pageSize uint64, boot time.Time) (Process, bool) {
+	open, end := strings.IndexByte(s, '('), strings.LastIndexByte(s, ')')
+	if open < 0 || end < open {
+		return Process{}, false
+	}
+	// 从第 3 个字段 state 开始：ppid 为 [1]，utime、stime 为 [11]、[12]，starttime 为 [19]，rss 为 [21]
+	fields := strings.Fields(s[end+1:])
+	if len(fields) < 22 {
+		return Process{}, false
+	}
+	ppid, _ := strconv.Atoi(fields[1])
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	start, _ := strconv.ParseUint(fields[19], 10, 64)
+	rss, _ := strconv.ParseUint(fields[21], 10, 64)
+	return Process{
+		PID:     pid,
+		PPID:    ppid,
+		Command: s[open+1 : end],
+		CPUTime: time.Duration(utime+stime) * time.Second / clockTicks,
+		RSS:     rss * pageSize,
+		Started: boot.Add(time.Duration(start) * time.Second / clockTicks),
+	}, true
+}
+
+// Cmdline 返回进程的完整命令行，读取失败（例如内核线程）时返回空
+func Cmdline(pid int) string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.ReplaceAll(string(data), "\x00", " "))
+}
+
+// netFiles 是 /proc/net 中的套接字表及对应的协议
+var netFiles = []struct{ name, proto string }{
+	{"tcp", "tcp"}, {"tcp6", "tcp"}, {"udp", "udp"}, {"udp6", "udp"},
+}
+
+// ListeningPorts 返回 pids 中每个进程监听的 TCP 端口和绑定的 UDP 端口
+// 通过 /proc/<pid>/fd 中的套接字 inode 与 /proc/net 中的条目对应，无权读取的进程会被跳过
+func ListeningPorts(pids []int) map[int][]Port {
+	sockets := make(map[string]Port)
+	for _, f := range netFiles {
+		if data, err := os.ReadFile("/proc/net/" + f.name); err == nil {
+			parseSockets(f.proto, string(data), sockets)
+		}
+	}
+
+	ports := make(map[int][]Port)
+	for _, pid := range pids {
+		dir := fmt.Sprintf("/proc/%d/fd", pid)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			link, err := os.Readlink(dir + "/" + e.Name())
+			if err != nil {
+				continue
+			}
+			inode, ok := strings.CutPrefix(link, "socket:[")
+			if !ok {
+				continue
+			}
+			if port, ok := sockets[strings.TrimSuffix(inode, "]")]; ok && !slices.Contains(ports[pid], port) {
+				ports[pid] = append(ports[pid], port)
+			}
+		}
+	}
+	return ports
+}
+
+// parseSockets 解析 /proc/net 中的套接字表，按 inode 记录监听的端口
+// TCP 只取 LISTEN（0A），UDP 只取未连接的套接字（07）
+func parseSockets(proto, table string, sockets map[string]Port) {
+	for _, line := range strings.Split(table, "\n")[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 10 {
+			continue
+		}
+		if (proto == "tcp" && fields[3] != "0A") || (proto == "udp" && fields[3] != "07") {
+			continue
+		}
+		if port, ok := parseAddr(proto, fields[1]); ok {
+			sockets[fields[9]] = port
+		}
+	}
+}
+
+// parseAddr 解析 /proc/net 中的地址，例如 0100007F:1F90 为 127.0.0.1:8080
+// 地址按 32 位字以主机字节序（小端）存储
+func parseAddr(proto, s string) (Port, bool) {
+	hexIP, hexPort, ok := strings.Cut(s, ":")
+	if !ok {
+		return Port{}, false
+	}
+	port, err := strconv.ParseUint(hexPort, 16, 16)
+	if err != nil {
+		return Port{}, false
+	}
+	raw, err := hex.DecodeString(hexIP)
+	if err != nil || (len(raw) != 4 && len(raw) != 16) {
+		return Port{}, false
+	}
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		ip[i], ip[i+1], ip[i+2], ip[i+3] = raw[i+3], raw[i+2], raw[i+1], raw[i]
+	}
+	return Port{Proto: proto, Addr: ip.String(), Port: int(port)}, true
+}
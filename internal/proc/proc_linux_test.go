@@ -0,0 +1,104 @@
+//go:build linux
+
+package proc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStat(t *testing.T) {
+	boot := time.Unix(1700000000, 0)
+	// utime 150 + stime 50 个时钟周期，starttime 500，rss 2048 页
+	const rest = " S 1 1234 1234 0 -1 4194560 1000 0 0 0 150 50 0 0 20 0 1 0 500 12345678 2048 18446744073709551615"
+
+	tests := []struct {
+		name    string
+		stat    string
+		command string
+		ok      bool
+	}{
+		{"plain", "1234 (bash)" + rest, "bash", true},
+		{"spaces", "1234 (tmux: server)" + rest, "tmux: server", true},
+		// 进程名可以包含右括号，以最后一个右括号为准
+		{"parentheses", "1234 (a) (b))" + rest, "a) (b)", true},
+		{"trailing newline", "1234 (vim)" + rest + "\n", "vim", true},
+		{"no parentheses", "1234 bash" + rest, "", false},
+		{"truncated", "1234 (bash) S 1 1234", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := parseStat(1234, tt.stat, 4096, boot)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			want := Process{
+				PID:     1234,
+				PPID:    1,
+				Command: tt.command,
+				CPUTime: 2 * time.Second,
+				RSS:     2048 * 4096,
+				Started: boot.Add(5 * time.Second),
+			}
+			if p != want {
+				t.Errorf("parseStat = %+v, want %+v", p, want)
+			}
+		})
+	}
+}
+
+func TestParseAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want Port
+		ok   bool
+	}{
+		{"0100007F:1F90", Port{"tcp", "127.0.0.1", 8080}, true},
+		{"00000000:0016", Port{"tcp", "0.0.0.0", 22}, true},
+		// IPv6 按 4 个 32 位字分别以小端存储
+		{"00000000000000000000000000000000:1F90", Port{"tcp", "::", 8080}, true},
+		{"00000000000000000000000001000000:0035", Port{"tcp", "::1", 53}, true},
+		{"000080FE00000000FF005002FF1A5BFE:0BB8", Port{"tcp", "fe80::250:ff:fe5b:1aff", 3000}, true},
+		{"0000000000000000FFFF00000100007F:1F91", Port{"tcp", "127.0.0.1", 8081}, true},
+		{"0100007F", Port{}, false},
+		{"0100007G:1F90", Port{}, false},
+		{"01000:1F90", Port{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseAddr("tcp", tt.addr)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseAddr(%q) = %+v, %v, want %+v, %v", tt.addr, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseSockets(t *testing.T) {
+	tcp6 := `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000000000000:1F90 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 41001 1 0000000000000000 100 0 0 10 0
+   1: 00000000000000000000000001000000:0016 00000000000000000000000001000000:D4C2 01 00000000:00000000 00:00000000 00000000     0        0 41002 1 0000000000000000 20 4 30 10 -1
+`
+	udp := `   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  100: 3500007F:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000   101        0 41003 2 0000000000000000 0
+  101: 0100007F:A1B2 0100007F:0035 01 00000000:00000000 00:00000000 00000000     0        0 41004 2 0000000000000000 0
+`
+	sockets := make(map[string]Port)
+	parseSockets("tcp", tcp6, sockets)
+	parseSockets("udp", udp, sockets)
+
+	// 已建立的连接不是监听端口
+	want := map[string]Port{
+		"41001": {"tcp", "::", 8080},
+		"41003": {"udp", "127.0.0.53", 53},
+	}
+	if len(sockets) != len(want) {
+		t.Fatalf("sockets = %v, want %v", sockets, want)
+	}
+	for inode, port := range want {
+		if sockets[inode] != port {
+			t.Errorf("sockets[%s] = %+v, want %+v", inode, sockets[inode], port)
+		}
+	}
+}
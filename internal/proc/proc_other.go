@@ -0,0 +1,20 @@
+//go:build !linux
+
+package proc
+
+import "errors"
+
+// Take 在没有 /proc 的平台上不可用
+func Take() (*Snapshot, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// Cmdline 在没有 /proc 的平台上不可用
+func Cmdline(pid int) string {
+	return ""
+}
+
+// ListeningPorts 在没有 /proc 的平台上不可用
+func ListeningPorts(pids []int) map[int][]Port {
+	return nil
+}
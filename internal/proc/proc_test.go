@@ -0,0 +1,89 @@
+package proc
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// testSnapshot 用 pid → ppid 构造快照
+func testSnapshot(parents map[int]int) *Snapshot {
+	processes := make(map[int]Process)
+	for pid, ppid := range parents {
+		processes[pid] = Process{PID: pid, PPID: ppid, Command: "p"}
+	}
+	return newSnapshot(processes)
+}
+
+func TestDescendants(t *testing.T) {
+	// 1 ─┬─ 10 ─┬─ 100
+	//    │      └─ 101 ── 1000
+	//    └─ 11
+	s := testSnapshot(map[int]int{1: 0, 10: 1, 11: 1, 100: 10, 101: 10, 1000: 101})
+
+	tests := []struct {
+		pid  int
+		want []int
+	}{
+		{1, []int{1, 10, 11, 100, 101, 1000}},
+		{10, []int{10, 100, 101, 1000}},
+		{11, []int{11}},
+		{42, nil},
+	}
+	for _, tt := range tests {
+		got := s.Descendants(tt.pid)
+		// 同一层的子进程来自 map，顺序不固定
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Descendants(%d) = %v, want %v", tt.pid, got, tt.want)
+		}
+	}
+}
+
+func TestTree(t *testing.T) {
+	prev := newSnapshot(map[int]Process{
+		1: {PID: 1, CPUTime: time.Second, RSS: 100},
+		2: {PID: 2, PPID: 1, CPUTime: 2 * time.Second, RSS: 200},
+	})
+	cur := newSnapshot(map[int]Process{
+		1: {PID: 1, CPUTime: 1500 * time.Millisecond, RSS: 100},
+		2: {PID: 2, PPID: 1, CPUTime: 2500 * time.Millisecond, RSS: 300},
+		// 两次快照之间启动的进程计入全部 CPU 时间
+		3: {PID: 3, PPID: 2, CPUTime: time.Second, RSS: 50},
+	})
+	cur.At = prev.At.Add(2 * time.Second)
+
+	u := cur.Tree(1, prev)
+	if u.RSS != 450 || u.CPU != 100 {
+		t.Errorf("Tree = %+v, want CPU 100 RSS 450", u)
+	}
+	if u := cur.Tree(1, nil); u.CPU != 0 || u.RSS != 450 {
+		t.Errorf("Tree without prev = %+v, want CPU 0 RSS 450", u)
+	}
+}
+
+func TestNodes(t *testing.T) {
+	// PID 取不存在的进程，Cmdline 读不到时使用进程名
+	const base = 1 << 30
+	s := newSnapshot(map[int]Process{
+		base:     {PID: base, Command: "bash"},
+		base + 3: {PID: base + 3, PPID: base, Command: "make"},
+		base + 1: {PID: base + 1, PPID: base, Command: "vim"},
+		base + 4: {PID: base + 4, PPID: base + 3, Command: "go"},
+		base + 9: {PID: base + 9, Command: "other"},
+	})
+
+	var got []string
+	for _, n := range s.Nodes(base) {
+		got = append(got, fmt.Sprintf("%d %s", n.Depth, n.Cmdline))
+	}
+	// 深度优先，同一层按 PID 排列
+	want := []string{"0 bash", "1 vim", "1 make", "2 go"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Nodes = %q, want %q", got, want)
+	}
+	if nodes := s.Nodes(42); nodes != nil {
+		t.Errorf("Nodes of a missing pid = %v, want nil", nodes)
+	}
+}
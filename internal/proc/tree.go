@@ -0,0 +1,82 @@
+package proc
+
+import (
+	"net"
+	"slices"
+	"strconv"
+
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// Port 是进程监听的端口
+type Port struct {
+	Proto string // tcp 或 udp
+	Addr  string // 监听地址，0.0.0.0 和 :: 表示所有地址
+	Port  int
+}
+
+// String 返回例如 tcp 127.0.0.1:8080、tcp [::]:8080
+func (p Port) String() string {
+	return p.Proto + " " + net.JoinHostPort(p.Addr, strconv.Itoa(p.Port))
+}
+
+// Node 是进程树中的一个进程
+type Node struct {
+	Process
+	Depth   int    // 相对窗格进程的层级，窗格进程为 0
+	Cmdline string // 完整命令行，读取不到时为进程名
+	Ports   []Port
+}
+
+// PaneTree 是窗格及其进程树
+type PaneTree struct {
+	Pane  tmux.Pane
+	Nodes []Node
+}
+
+// Nodes 按深度优先顺序返回 pid 及其子孙进程，同一层按 PID 排列
+func (s *Snapshot) Nodes(pid int) []Node {
+	proc, ok := s.Processes[pid]
+	if !ok {
+		return nil
+	}
+	var nodes []Node
+	var walk func(p Process, depth int)
+	walk = func(p Process, depth int) {
+		cmdline := Cmdline(p.PID)
+		if cmdline == "" {
+			cmdline = p.Command
+		}
+		nodes = append(nodes, Node{Process: p, Depth: depth, Cmdline: cmdline})
+		children := slices.Clone(s.children[p.PID])
+		slices.Sort(children)
+		for _, child := range children {
+			walk(s.Processes[child], depth+1)
+		}
+	}
+	walk(proc, 0)
+	return nodes
+}
+
+// Trees 读取每个窗格的进程树及其中进程监听的端口
+func Trees(panes []tmux.Pane) ([]PaneTree, error) {
+	snapshot, err := Take()
+	if err != nil {
+		return nil, err
+	}
+	trees := make([]PaneTree, len(panes))
+	var pids []int
+	for i, p := range panes {
+		trees[i] = PaneTree{Pane: p, Nodes: snapshot.Nodes(p.PID)}
+		for _, n := range trees[i].Nodes {
+			pids = append(pids, n.PID)
+		}
+	}
+	ports := ListeningPorts(pids)
+	for _, t := range trees {
+		for i := range t.Nodes {
+			t.Nodes[i].Ports = ports[t.Nodes[i].PID]
+		}
+	}
+	return trees, nil
+}
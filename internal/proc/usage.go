@@ -0,0 +1,56 @@
+package proc
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// Resources 是按窗格、窗口和会话汇总的资源占用
+type Resources struct {
+	Panes    map[string]Usage // 按窗格 ID
+	Windows  map[string]Usage // 按窗口目标，例如 dev:1
+	Sessions map[string]Usage
+}
+
+// Collect 统计每个窗格中 pane_pid 及其子孙进程的资源占用，并汇总到窗口和会话
+func Collect(panes []tmux.Pane, cur, prev *Snapshot) Resources {
+	r := Resources{
+		Panes:    make(map[string]Usage),
+		Windows:  make(map[string]Usage),
+		Sessions: make(map[string]Usage),
+	}
+	for _, p := range panes {
+		u := cur.Tree(p.PID, prev)
+		window := fmt.Sprintf("%s:%d", p.Session, p.Window)
+		r.Panes[p.ID] = u
+		r.Windows[window] = r.Windows[window].Add(u)
+		r.Sessions[p.Session] = r.Sessions[p.Session].Add(u)
+	}
+	return r
+}
+
+// SortKey 是会话按资源占用排序的方式
+type SortKey int
+
+const (
+	SortNone SortKey = iota // 保持 tmux 的顺序
+	SortCPU
+	SortRSS
+)
+
+// SortSessions 按资源占用从高到低排列会话，占用相同时保持原来的顺序
+func (r Resources) SortSessions(sessions []tmux.Session, key SortKey) {
+	if key == SortNone {
+		return
+	}
+	slices.SortStableFunc(sessions, func(a, b tmux.Session) int {
+		ua, ub := r.Sessions[a.Name], r.Sessions[b.Name]
+		if key == SortCPU {
+			return cmp.Compare(ub.CPU, ua.CPU)
+		}
+		return cmp.Compare(ub.RSS, ua.RSS)
+	})
+}
@@ -0,0 +1,59 @@
+// Package reap 根据配置的策略找出可以清理的空闲会话
+package reap
+
+import (
+	"cmp"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+// Policy 是清理空闲会话的策略，会话需要同时满足所有条件
+type Policy struct {
+	Idle    time.Duration // 空闲超过这个时长
+	Match   []string      // 会话名匹配其中一个模式（filepath.Match 语法），为空时匹配所有会话
+	Pinned  []string      // 匹配这些模式的会话永远保留
+	Exclude string        // 不清理的会话，例如运行 tmx 的当前会话
+}
+
+// Candidate 是可以清理的会话
+type Candidate struct {
+	Session tmux.Session
+	Idle    time.Duration
+}
+
+// Candidates 返回满足策略的会话，空闲最久的在前
+// 已连接的会话和标记为保留的会话（@tmx_pinned 或 Pinned 中的模式）不会被清理
+func (p Policy) Candidates(sessions []tmux.Session) []Candidate {
+	var candidates []Candidate
+	for _, s := range sessions {
+		idle := s.Idle()
+		if s.Attached || p.IsPinned(s) || s.Name == p.Exclude || idle < p.Idle {
+			continue
+		}
+		if len(p.Match) > 0 && !matchAny(p.Match, s.Name) {
+			continue
+		}
+		candidates = append(candidates, Candidate{Session: s, Idle: idle})
+	}
+	slices.SortStableFunc(candidates, func(a, b Candidate) int {
+		return cmp.Compare(b.Idle, a.Idle)
+	})
+	return candidates
+}
+
+// IsPinned 返回会话是否标记为保留
+func (p Policy) IsPinned(s tmux.Session) bool {
+	return s.Pinned || matchAny(p.Pinned, s.Name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package reap
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+const day = 24 * time.Hour
+
+// idleSession 返回空闲了 idle 的会话：创建、窗口输出、客户端输入和最近连接都在 idle 之前
+func idleSession(name string, idle time.Duration) tmux.Session {
+	t := time.Now().Add(-idle)
+	return tmux.Session{Name: name, Created: t.Add(-time.Hour), Activity: t.Add(-time.Minute), Output: t, LastAttached: t.Add(-time.Minute)}
+}
+
+func TestCandidates(t *testing.T) {
+	attached := idleSession("attached", 30*day)
+	attached.Attached = true
+	pinned := idleSession("pinned", 30*day)
+	pinned.Pinned = true
+	// 最近连接过的会话，即使很久没有输出也不算空闲
+	visited := idleSession("visited", 30*day)
+	visited.LastAttached = time.Now().Add(-time.Hour)
+	// 很久没人输入、但窗格仍在输出（例如构建或写日志的服务）的会话不算空闲
+	busy := idleSession("busy", 30*day)
+	busy.Output = time.Now().Add(-time.Minute)
+
+	sessions := []tmux.Session{
+		idleSession("fresh", 2*day),
+		idleSession("old", 10*day),
+		idleSession("older", 20*day),
+		idleSession("tmp-1", 8*day),
+		idleSession("keep-db", 40*day),
+		idleSession("current", 50*day),
+		attached,
+		pinned,
+		visited,
+		busy,
+	}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		want   []string
+	}{
+		{
+			name:   "idle threshold",
+			policy: Policy{Idle: 7 * day},
+			want:   []string{"current", "keep-db", "older", "old", "tmp-1"},
+		},
+		{
+			name:   "longer threshold",
+			policy: Policy{Idle: 15 * day},
+			want:   []string{"current", "keep-db", "older"},
+		},
+		{
+			name:   "excluded",
+			policy: Policy{Idle: 7 * day, Exclude: "current"},
+			want:   []string{"keep-db", "older", "old", "tmp-1"},
+		},
+		{
+			name:   "pinned patterns",
+			policy: Policy{Idle: 7 * day, Pinned: []string{"keep-*", "old"}},
+			want:   []string{"current", "older", "tmp-1"},
+		},
+		{
+			name:   "match patterns",
+			policy: Policy{Idle: 7 * day, Match: []string{"tmp-*", "older"}},
+			want:   []string{"older", "tmp-1"},
+		},
+		{
+			name:   "match and pinned",
+			policy: Policy{Idle: 7 * day, Match: []string{"*"}, Pinned: []string{"*"}},
+			want:   nil,
+		},
+		{
+			// 清理时长为 0 时所有未连接、未保留的会话都可以清理
+			name:   "no threshold",
+			policy: Policy{Match: []string{"fresh", "visited", "busy", "attached", "pinned"}},
+			want:   []string{"fresh", "visited", "busy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range tt.policy.Candidates(sessions) {
+				got = append(got, c.Session.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Candidates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPinned(t *testing.T) {
+	p := Policy{Pinned: []string{"prod-*", "notes"}}
+	tests := []struct {
+		session tmux.Session
+		want    bool
+	}{
+		{tmux.Session{Name: "prod-api"}, true},
+		{tmux.Session{Name: "notes"}, true},
+		{tmux.Session{Name: "notes-old"}, false},
+		{tmux.Session{Name: "dev", Pinned: true}, true},
+		{tmux.Session{Name: "dev"}, false},
+	}
+	for _, tt := range tests {
+		if got := p.IsPinned(tt.session); got != tt.want {
+			t.Errorf("IsPinned(%s, pinned=%v) = %v, want %v", tt.session.Name, tt.session.Pinned, got, tt.want)
+		}
+	}
+}
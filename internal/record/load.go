@@ -0,0 +1,97 @@
+package record
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// idleLimit 是回放时两行之间的最长间隔，更长的空闲会被压缩
+const idleLimit = 2 * time.Second
+
+// Entry 是录制中的一行输出
+type Entry struct {
+	At     time.Time     // 输出的时间
+	Offset time.Duration // 回放时间轴上的位置
+	Text   string
+}
+
+// Load 读取录制文件，.cast 按 asciicast v2 解析，其他文件按带时间戳的日志解析
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var entries []Entry
+	if strings.HasSuffix(path, ".cast") {
+		entries, err = loadCast(scanner)
+	} else {
+		entries, err = loadLog(scanner)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	for i := 1; i < len(entries); i++ {
+		gap := min(max(entries[i].At.Sub(entries[i-1].At), 0), idleLimit)
+		entries[i].Offset = entries[i-1].Offset + gap
+	}
+	return entries, nil
+}
+
+// loadLog 解析 tmx __record 写入的日志，没有时间戳的行沿用上一行的时间
+func loadLog(scanner *bufio.Scanner) ([]Entry, error) {
+	var entries []Entry
+	var last time.Time
+	for scanner.Scan() {
+		line := scanner.Text()
+		entry := Entry{At: last, Text: line}
+		if len(line) > len(TimeLayout) {
+			if at, err := time.ParseInLocation(TimeLayout, line[:len(TimeLayout)], time.Local); err == nil {
+				entry = Entry{At: at, Text: line[len(TimeLayout)+1:]}
+				last = at
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// loadCast 解析 asciicast v2 文件，将输出事件拆分为行
+func loadCast(scanner *bufio.Scanner) ([]Entry, error) {
+	if !scanner.Scan() {
+		return nil, scanner.Err()
+	}
+	var header castHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, fmt.Errorf("invalid asciicast header: %w", err)
+	}
+	start := time.Unix(header.Timestamp, 0)
+
+	var entries []Entry
+	lines := lineSplitter{emit: func(at time.Time, line string) {
+		entries = append(entries, Entry{At: at, Text: line})
+	}}
+	for scanner.Scan() {
+		var event []any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || len(event) < 3 {
+			continue
+		}
+		seconds, _ := event[0].(float64)
+		kind, _ := event[1].(string)
+		data, _ := event[2].(string)
+		if kind == "o" {
+			lines.write([]byte(data), start.Add(time.Duration(seconds*float64(time.Second))))
+		}
+	}
+	lines.flush()
+	return entries, scanner.Err()
+}
@@ -0,0 +1,134 @@
+package record
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFixture(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func texts(entries []Entry) []string {
+	var out []string
+	for _, e := range entries {
+		out = append(out, e.Text)
+	}
+	return out
+}
+
+func offsets(entries []Entry) []time.Duration {
+	var out []time.Duration
+	for _, e := range entries {
+		out = append(out, e.Offset)
+	}
+	return out
+}
+
+func TestLoadLog(t *testing.T) {
+	path := writeFixture(t, "dev_1.0.log", `2026-10-19 10:00:00.000 $ make
+2026-10-19 10:00:00.500 go build ./...
+panic: boom
+2026-10-19 10:00:30.500 $ ls
+2026-10-19 10:00:29.000 a.txt
+2026-10-19 10:00:29.000 
+2026-10-19 10:00:30.250 b | c
+`)
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 没有时间戳的行沿用上一行的时间，空行保留
+	wantTexts := []string{"$ make", "go build ./...", "panic: boom", "$ ls", "a.txt", "", "b | c"}
+	if got := texts(entries); !slices.Equal(got, wantTexts) {
+		t.Errorf("texts = %q, want %q", got, wantTexts)
+	}
+	if !entries[2].At.Equal(entries[1].At) {
+		t.Errorf("line without timestamp at %v, want %v", entries[2].At, entries[1].At)
+	}
+	// 30 秒的空闲压缩为 2 秒，时间倒退按 0 计算
+	ms := time.Millisecond
+	wantOffsets := []time.Duration{0, 500 * ms, 500 * ms, 2500 * ms, 2500 * ms, 2500 * ms, 3750 * ms}
+	if got := offsets(entries); !slices.Equal(got, wantOffsets) {
+		t.Errorf("offsets = %v, want %v", got, wantOffsets)
+	}
+}
+
+func TestLoadCast(t *testing.T) {
+	path := writeFixture(t, "dev_1.0.cast", `{"version": 2, "width": 80, "height": 24, "timestamp": 1760000000}
+[0.1, "o", "$ echo hi\r\n"]
+[0.2, "i", "ignored input\r"]
+[0.3, "o", "\u001b[32mhi\u001b[0m\r\nprogress 10%"]
+not json
+[0.9, "o", "\rprogress 100%\r\n"]
+[15.0, "o", "par"]
+[15.5, "o", "tial"]
+`)
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 去掉颜色，回车之后的内容覆盖之前的内容，最后未换行的输出也保留
+	wantTexts := []string{"$ echo hi", "hi", "progress 100%", "partial"}
+	if got := texts(entries); !slices.Equal(got, wantTexts) {
+		t.Errorf("texts = %q, want %q", got, wantTexts)
+	}
+	start := time.Unix(1760000000, 0)
+	if want := start.Add(100 * time.Millisecond); !entries[0].At.Equal(want) {
+		t.Errorf("first line at %v, want %v", entries[0].At, want)
+	}
+	// 每行的时间是它第一段输出的时间（进度行从 0.3 秒开始），十几秒的空闲压缩为 2 秒
+	ms := time.Millisecond
+	wantOffsets := []time.Duration{0, 200 * ms, 200 * ms, 2200 * ms}
+	got := offsets(entries)
+	if len(got) != len(wantOffsets) {
+		t.Fatalf("offsets = %v, want %v", got, wantOffsets)
+	}
+	for i := range got {
+		if d := got[i] - wantOffsets[i]; d < -ms || d > ms {
+			t.Errorf("offsets = %v, want %v", got, wantOffsets)
+			break
+		}
+	}
+}
+
+func TestLoadCastInvalidHeader(t *testing.T) {
+	path := writeFixture(t, "broken.cast", "[0.1, \"o\", \"hi\"]\n")
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "asciicast header") {
+		t.Errorf("Load error = %v, want an invalid header error", err)
+	}
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	logPath, castPath := filepath.Join(dir, "p.log"), filepath.Join(dir, "p.cast")
+	r, err := NewRecorder(logPath, castPath, 80, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Run(strings.NewReader("$ make\r\n\x1b[1mok\x1b[0m\r\n日志\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"$ make", "ok", "日志"}
+	for _, path := range []string{logPath, castPath} {
+		entries, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := texts(entries); !slices.Equal(got, want) {
+			t.Errorf("%s: texts = %q, want %q", filepath.Base(path), got, want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Package record 实现基于 pipe-pane 的窗格输出录制和回放
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TimeLayout 是日志每行开头的时间格式
+const TimeLayout = "2006-01-02 15:04:05.000"
+
+// Options 描述一次录制
+type Options struct {
+	Dir  string // 录制文件所在的目录
+	Cast bool   // 同时写入 asciicast v2 文件
+}
+
+// Start 开始录制窗格的输出，返回日志文件路径
+// tmux 将窗格输出通过管道交给 tmx __record，由它写入带时间戳的日志
+func Start(m *tmux.Manager, p tmux.Pane, opts Options) (string, error) {
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", opts.Dir, err)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s_%d.%d-%s", strings.ReplaceAll(p.Session, "/", "_"), p.Window, p.Index,
+		time.Now().Format("20060102-150405"))
+	logPath := filepath.Join(opts.Dir, name+".log")
+	args := []string{exe, "__record", "--log", logPath}
+	if opts.Cast {
+		args = append(args, "--cast", filepath.Join(opts.Dir, name+".cast"),
+			"--size", fmt.Sprintf("%dx%d", p.Width, p.Height))
+	}
+
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = tmux.ShellQuote(arg)
+	}
+	if err := m.StartPipe(p.ID, "exec "+strings.Join(quoted, " "), logPath); err != nil {
+		return "", err
+	}
+	return logPath, nil
+}
+
+// Stop 停止录制窗格的输出
+func Stop(m *tmux.Manager, p tmux.Pane) error {
+	return m.StopPipe(p.ID)
+}
+
+// castHeader 是 asciicast v2 文件的第一行
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder 将窗格输出写入日志和 asciicast 文件
+type Recorder struct {
+	log   *os.File
+	cast  *os.File
+	start time.Time
+	lines lineSplitter
+}
+
+// NewRecorder 创建录制文件，castPath 为空时不写 asciicast
+func NewRecorder(logPath, castPath string, width, height int) (*Recorder, error) {
+	// asciicast 头部只记录到秒，事件时间也从整秒开始计算
+	r := &Recorder{start: time.Now().Truncate(time.Second)}
+	r.lines.emit = func(at time.Time, line string) {
+		fmt.Fprintf(r.log, "%s %s\n", at.Format(TimeLayout), line)
+	}
+
+	var err error
+	r.log, err = os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if castPath == "" {
+		return r, nil
+	}
+
+	r.cast, err = os.Create(castPath)
+	if err != nil {
+		r.log.Close()
+		return nil, err
+	}
+	header, _ := json.Marshal(castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: r.start.Unix(),
+		Env:       map[string]string{"TERM": os.Getenv("TERM")},
+	})
+	fmt.Fprintf(r.cast, "%s\n", header)
+	return r, nil
+}
+
+// Run 读取输出直到 EOF（窗格关闭或停止录制），然后关闭文件
+func (r *Recorder) Run(in io.Reader) error {
+	defer r.Close()
+
+	buf := make([]byte, 32*1024)
+	pending := 0 // 上次读取末尾不完整的 UTF-8 字节
+	for {
+		n, err := in.Read(buf[pending:])
+		n += pending
+		if n > 0 {
+			// 多字节字符可能被拆到两次读取中，留到下一次再写
+			complete := utf8Prefix(buf[:n])
+			if err != nil {
+				complete = n
+			}
+			if complete > 0 {
+				r.Write(buf[:complete], time.Now())
+			}
+			pending = copy(buf, buf[complete:n])
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Write 记录一段在 at 时刻产生的输出
+func (r *Recorder) Write(data []byte, at time.Time) {
+	if r.cast != nil {
+		event, _ := json.Marshal([]any{at.Sub(r.start).Seconds(), "o", string(data)})
+		fmt.Fprintf(r.cast, "%s\n", event)
+	}
+
+	r.lines.write(data, at)
+}
+
+// Close 写入未换行的输出并关闭文件
+func (r *Recorder) Close() error {
+	r.lines.flush()
+	if r.cast != nil {
+		r.cast.Close()
+	}
+	return r.log.Close()
+}
+
+// utf8Prefix 返回 b 中以完整 UTF-8 字符结尾的前缀长度
+func utf8Prefix(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if utf8.FullRune(b[i:]) {
+				return len(b)
+			}
+			return i
+		}
+	}
+	return len(b)
+}
+
+// lineSplitter 将输出拆分为行，每行带有第一段输出的时间
+type lineSplitter struct {
+	partial strings.Builder // 尚未换行的输出
+	stamp   time.Time
+	emit    func(at time.Time, line string)
+}
+
+func (l *lineSplitter) write(data []byte, at time.Time) {
+	for len(data) > 0 {
+		if l.partial.Len() == 0 {
+			l.stamp = at
+		}
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			l.partial.Write(data)
+			return
+		}
+		l.partial.Write(data[:i])
+		l.line()
+		data = data[i+1:]
+	}
+}
+
+// flush 输出最后一段未换行的内容
+func (l *lineSplitter) flush() {
+	if l.partial.Len() > 0 {
+		l.line()
+	}
+}
+
+// line 输出当前行：去掉转义序列，回车之后的内容覆盖之前的内容
+func (l *lineSplitter) line() {
+	line := strings.TrimRight(l.partial.String(), "\r")
+	line = ansi.Strip(line[strings.LastIndex(line, "\r")+1:])
+	l.partial.Reset()
+	l.emit(l.stamp, line)
+}
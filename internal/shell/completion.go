@@ -0,0 +1,247 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ArgKind 描述参数值的补全来源
+type ArgKind int
+
+const (
+	ArgNone    ArgKind = iota // 无参数值
+	ArgText                   // 任意文本，不补全
+	ArgShell                  // bash、zsh、fish
+	ArgSession                // 会话名称
+	ArgWindow                 // 窗口目标（会话:索引）
+	ArgTheme                  // 主题名称
+	ArgTarget                 // 会话名称或窗口目标
+	ArgArchive                // 存档中的会话名称
+)
+
+// Flag 是命令的参数，Value 不为 ArgNone 时需要跟一个值
+type Flag struct {
+	Name  string
+	Value ArgKind
+	// Choices 不为空时是参数值的固定候选
+	Choices []string
+}
+
+// Command 描述一个子命令及其参数，用于生成补全
+type Command struct {
+	Name        string
+	Flags       []Flag
+	Subcommands []Command
+	// Args 位置参数的补全来源，Variadic 为 true 时最后一种可重复
+	Args     []ArgKind
+	Variadic bool
+	// Hidden 不出现在补全候选中（内部使用的参数）
+	Hidden bool
+}
+
+// Source 提供动态补全数据
+type Source interface {
+	Sessions() []string
+	Windows() []string
+	Themes() []string
+	Archives() []string
+}
+
+// Complete 根据已输入的单词返回补全候选
+// words 是 tmx 之后的全部单词，最后一个是正在输入的单词（可能为空）
+func Complete(commands []Command, words []string, src Source) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+	typed := words[:len(words)-1]
+
+	// 沿子命令逐层定位
+	level := Command{Subcommands: commands}
+	i := 0
+	for i < len(typed) {
+		sub, ok := findCommand(level.Subcommands, typed[i])
+		if !ok {
+			break
+		}
+		level = sub
+		i++
+	}
+	rest := typed[i:]
+
+	// 上一个单词是需要值的参数
+	if len(rest) > 0 {
+		if flag, ok := findFlag(level.Flags, rest[len(rest)-1]); ok && flag.Value != ArgNone {
+			if len(flag.Choices) > 0 {
+				return filterPrefix(flag.Choices, current)
+			}
+			return filterPrefix(values(flag.Value, src), current)
+		}
+	}
+
+	if strings.HasPrefix(current, "-") {
+		return filterPrefix(unusedFlags(level, rest), current)
+	}
+
+	// 还没有位置参数时可以继续输入子命令
+	var candidates []string
+	positional := positionalCount(level, rest)
+	if positional == 0 {
+		for _, sub := range level.Subcommands {
+			if !sub.Hidden {
+				candidates = append(candidates, sub.Name)
+			}
+		}
+	}
+	if kind, ok := argKind(level, positional); ok {
+		candidates = append(candidates, values(kind, src)...)
+	}
+	if current == "" && len(candidates) == 0 {
+		candidates = unusedFlags(level, rest)
+	}
+	return filterPrefix(candidates, current)
+}
+
+func findCommand(commands []Command, name string) (Command, bool) {
+	for _, c := range commands {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Command{}, false
+}
+
+func findFlag(flags []Flag, name string) (Flag, bool) {
+	for _, f := range flags {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Flag{}, false
+}
+
+// unusedFlags 返回尚未输入过的参数名
+func unusedFlags(c Command, typed []string) []string {
+	used := make(map[string]bool, len(typed))
+	for _, w := range typed {
+		used[w] = true
+	}
+	var names []string
+	for _, f := range c.Flags {
+		if !used[f.Name] {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// positionalCount 统计已输入的位置参数个数（跳过参数及其值）
+func positionalCount(c Command, typed []string) int {
+	n := 0
+	for i := 0; i < len(typed); i++ {
+		if flag, ok := findFlag(c.Flags, typed[i]); ok {
+			if flag.Value != ArgNone {
+				i++
+			}
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+func argKind(c Command, position int) (ArgKind, bool) {
+	if position < len(c.Args) {
+		return c.Args[position], true
+	}
+	if c.Variadic && len(c.Args) > 0 {
+		return c.Args[len(c.Args)-1], true
+	}
+	return ArgNone, false
+}
+
+func values(kind ArgKind, src Source) []string {
+	switch kind {
+	case ArgShell:
+		return Shells
+	case ArgSession:
+		return src.Sessions()
+	case ArgWindow:
+		return src.Windows()
+	case ArgTheme:
+		return src.Themes()
+	case ArgTarget:
+		return append(src.Sessions(), src.Windows()...)
+	case ArgArchive:
+		return src.Archives()
+	}
+	return nil
+}
+
+func filterPrefix(candidates []string, prefix string) []string {
+	var out []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Completion 返回指定 shell 的补全脚本，候选由 tmx __complete 动态生成
+func Completion(shell string) (string, error) {
+	switch shell {
+	case "bash":
+		return bashScript, nil
+	case "zsh":
+		return zshScript, nil
+	case "fish":
+		return fishScript, nil
+	}
+	return "", fmt.Errorf("unsupported shell %q (supported: %s)", shell, strings.Join(Shells, ", "))
+}
+
+// bash 按 COMP_WORDBREAKS 把 dev:1 这样的窗口目标拆成 dev、:、1 三个单词，
+// 补全前需要合并回一个单词，补全结果也只能替换最后一个冒号之后的部分。
+// 有 bash-completion 时使用它的函数，否则自行处理
+const bashScript = `# tmx bash completion (source <(tmx completion bash))
+_tmx_complete() {
+    local IFS=$'\n' cur words cword i
+    if declare -F _get_comp_words_by_ref >/dev/null; then
+        _get_comp_words_by_ref -n : cur words cword
+    else
+        words=("${COMP_WORDS[0]}") cword=0
+        for ((i = 1; i <= COMP_CWORD; i++)); do
+            if ((i > 1)) && [[ ${COMP_WORDS[i]} == : || ${COMP_WORDS[i-1]} == : ]]; then
+                words[cword]+=${COMP_WORDS[i]}
+            else
+                words[++cword]=${COMP_WORDS[i]}
+            fi
+        done
+        cur=${words[cword]}
+    fi
+    COMPREPLY=($(tmx __complete "${words[@]:1:cword}" 2>/dev/null))
+    if declare -F __ltrim_colon_completions >/dev/null; then
+        __ltrim_colon_completions "$cur"
+    elif [[ $cur == *:* && $COMP_WORDBREAKS == *:* ]]; then
+        local prefix=${cur%"${cur##*:}"}
+        COMPREPLY=("${COMPREPLY[@]#"$prefix"}")
+    fi
+}
+complete -F _tmx_complete tmx
+`
+
+const zshScript = `# tmx zsh completion (source <(tmx completion zsh))
+_tmx() {
+    local -a candidates
+    candidates=("${(@f)$(tmx __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    compadd -a candidates
+}
+if (( $+functions[compdef] )); then
+    compdef _tmx tmx
+fi
+`
+
+const fishScript = `# tmx fish completion (tmx completion fish | source)
+complete -c tmx -f -a '(tmx __complete (commandline -opc)[2..-1] (commandline -ct))'
+`
@@ -0,0 +1,159 @@
+// Package shell 生成 tmx 的 shell 集成代码（tmx init）
+package shell
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+)
+
+// Shells 是 tmx init 支持的 shell
+var Shells = []string{"bash", "zsh", "fish"}
+
+// LoadedEnv 是集成代码导出的环境变量，值为加载集成代码的 shell 的 PID
+// 子进程看不到 shell 函数，tmx doctor 据此判断当前 shell 是否已加载集成
+const LoadedEnv = "TMX_SHELL_PID"
+
+// InitOptions 控制 tmx init 生成的内容
+type InitOptions struct {
+	// AutoAttach 在终端启动（且不在 tmux 中）时自动连接 tmux
+	AutoAttach bool
+	// Alias 快速切换命令的名称，为空时不生成
+	Alias string
+}
+
+var aliasPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Init 返回供 eval 的 shell 集成代码
+func Init(shell string, opts InitOptions) (string, error) {
+	if opts.Alias != "" && !aliasPattern.MatchString(opts.Alias) {
+		return "", fmt.Errorf("invalid alias name %q", opts.Alias)
+	}
+
+	switch shell {
+	case "bash", "zsh":
+		return posixInit(shell, opts), nil
+	case "fish":
+		return fishInit(opts), nil
+	}
+	return "", fmt.Errorf("unsupported shell %q (supported: %s)", shell, strings.Join(Shells, ", "))
+}
+
+// posixInit 生成 bash/zsh 通用的集成代码
+func posixInit(shell string, opts InitOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# tmx shell integration (eval \"$(tmx init %s)\")\n\n", shell)
+	fmt.Fprintf(&b, "# %s\nexport %s=$$\n\n", i18n.T("init.loaded_comment"), LoadedEnv)
+
+	fmt.Fprintf(&b, `# %s
+__tmx_detach() {
+    if [ -n "$TMUX" ]; then
+        tmux detach-client
+    else
+        echo "%s" >&2
+        return 1
+    fi
+}
+
+# %s
+if ! type quit >/dev/null 2>&1; then
+    quit() { __tmx_detach; }
+fi
+if ! type detach >/dev/null 2>&1; then
+    detach() { __tmx_detach; }
+fi
+`, i18n.T("init.detach_comment"), i18n.T("install.quit_not_in_tmux"), i18n.T("init.no_override_comment"))
+
+	if opts.Alias != "" {
+		fmt.Fprintf(&b, `
+# %s
+%s() {
+    if [ $# -eq 0 ]; then
+        tmx
+    elif [ -n "$TMUX" ]; then
+        tmux switch-client -t "$1"
+    else
+        tmux attach-session -t "$1"
+    fi
+}
+`, i18n.T("init.alias_comment"), opts.Alias)
+	}
+
+	b.WriteString("\n" + completion(shell))
+
+	if opts.AutoAttach {
+		fmt.Fprintf(&b, `
+# %s
+if [ -z "$TMUX" ] && [ -z "$TMX_NO_AUTO_ATTACH" ] && [ -t 0 ] && [ -t 1 ]; then
+    tmux new-session -A -s default
+fi
+`, i18n.T("init.auto_attach_comment"))
+	}
+	return b.String()
+}
+
+// fishInit 生成 fish 的集成代码
+func fishInit(opts InitOptions) string {
+	var b strings.Builder
+	b.WriteString("# tmx shell integration (tmx init fish | source)\n\n")
+	fmt.Fprintf(&b, "# %s\nset -gx %s $fish_pid\n\n", i18n.T("init.loaded_comment"), LoadedEnv)
+
+	fmt.Fprintf(&b, `# %s
+function __tmx_detach
+    if set -q TMUX
+        tmux detach-client
+    else
+        echo "%s" >&2
+        return 1
+    end
+end
+
+# %s
+if not type -q quit
+    function quit
+        __tmx_detach
+    end
+end
+if not type -q detach
+    function detach
+        __tmx_detach
+    end
+end
+`, i18n.T("init.detach_comment"), i18n.T("install.quit_not_in_tmux"), i18n.T("init.no_override_comment"))
+
+	if opts.Alias != "" {
+		fmt.Fprintf(&b, `
+# %s
+function %s
+    if test (count $argv) -eq 0
+        tmx
+    else if set -q TMUX
+        tmux switch-client -t $argv[1]
+    else
+        tmux attach-session -t $argv[1]
+    end
+end
+`, i18n.T("init.alias_comment"), opts.Alias)
+	}
+
+	b.WriteString("\n" + completion("fish"))
+
+	if opts.AutoAttach {
+		fmt.Fprintf(&b, `
+# %s
+if status is-interactive; and not set -q TMUX; and not set -q TMX_NO_AUTO_ATTACH
+    tmux new-session -A -s default
+end
+`, i18n.T("init.auto_attach_comment"))
+	}
+	return b.String()
+}
+
+// completion 返回 init 中注册补全的部分，去掉独立脚本的首行用法说明
+func completion(shell string) string {
+	script, _ := Completion(shell)
+	_, body, _ := strings.Cut(script, "\n")
+	return "# " + i18n.T("init.completion_comment") + "\n" + body
+}
@@ -0,0 +1,52 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Alerts 是窗口的提醒标记，在窗口成为客户端的当前窗口后清除
+type Alerts struct {
+	Activity bool // 有新的输出（需要 monitor-activity）
+	Bell     bool // 响铃
+	Silence  bool // 超过 monitor-silence 秒没有输出
+}
+
+// Any 检查是否有任何提醒
+func (a Alerts) Any() bool {
+	return a.Activity || a.Bell || a.Silence
+}
+
+// parseSessionAlerts 解析 #{session_alerts}，例如 "1#,2!~"
+// # 表示活动，! 表示响铃，~ 表示静默
+func parseSessionAlerts(s string) Alerts {
+	return Alerts{
+		Activity: strings.Contains(s, "#"),
+		Bell:     strings.Contains(s, "!"),
+		Silence:  strings.Contains(s, "~"),
+	}
+}
+
+// SetMonitorActivity 开关窗口的 monitor-activity
+func (m *Manager) SetMonitorActivity(target string, on bool) error {
+	value := "off"
+	if on {
+		value = "on"
+	}
+	return setWindowOption(target, "monitor-activity", value)
+}
+
+// SetMonitorSilence 设置窗口的 monitor-silence，seconds 为 0 时关闭
+func (m *Manager) SetMonitorSilence(target string, seconds int) error {
+	return setWindowOption(target, "monitor-silence", strconv.Itoa(seconds))
+}
+
+func setWindowOption(target, option, value string) error {
+	output, err := exec.Command("tmux", "set-window-option", "-t", target, option, value).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to set %s on %s: %s", option, target, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
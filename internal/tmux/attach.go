@@ -0,0 +1,124 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// AttachOptions 控制连接会话的方式
+type AttachOptions struct {
+	// ReadOnly 以只读方式连接，只能查看不能输入
+	ReadOnly bool
+	// DetachOthers 断开该会话上的其它客户端
+	DetachOthers bool
+	// Grouped 新建一个与目标会话同组的会话再连接
+	// 同组会话共享窗口，但各自选择当前窗口，适合两人查看同一会话的不同窗口
+	Grouped bool
+	// Client 在 tmux 中要切换的客户端，为空时使用当前客户端
+	Client string
+}
+
+// Attach 按选项连接到目标，target 可以是会话、窗口（dev:1）或窗格（dev:1.0）
+// 在 tmux 中切换客户端；在 tmux 外以 tmux attach-session 替换当前进程
+func (m *Manager) Attach(target string, opts AttachOptions) error {
+	session, rest := splitTarget(target)
+	grouped := ""
+	if opts.Grouped {
+		name, err := m.NewGroupedSession(session)
+		if err != nil {
+			return err
+		}
+		grouped = name
+		target = grouped + rest
+	}
+
+	client := opts.Client
+	if client == "" {
+		client = currentClient()
+	}
+	// 断开的是原会话上的客户端：Grouped 时连接的是新建的同组会话，上面还没有其它客户端
+	if opts.DetachOthers {
+		if err := m.detachOtherClients(session, client); err != nil {
+			return err
+		}
+	}
+
+	if !inTmuxSession() && opts.Client == "" {
+		args := []string{"attach-session", "-t", target}
+		if opts.ReadOnly {
+			args = append(args, "-r")
+		}
+		// 连接后再设置自动销毁，否则分组会话在连接前就会被销毁
+		if grouped != "" {
+			args = append(args, ";", "set-option", "-t", grouped, "destroy-unattached", "on")
+		}
+		return execTmux(args...)
+	}
+
+	if err := m.SwitchClient(client, target); err != nil {
+		return fmt.Errorf("failed to switch client: %w", err)
+	}
+	if grouped != "" {
+		if err := exec.Command("tmux", "set-option", "-t", grouped, "destroy-unattached", "on").Run(); err != nil {
+			return fmt.Errorf("failed to set destroy-unattached: %w", err)
+		}
+	}
+	if opts.ReadOnly {
+		args := []string{"refresh-client", "-f", "read-only"}
+		if client != "" {
+			args = append(args, "-t", client)
+		}
+		if err := exec.Command("tmux", args...).Run(); err != nil {
+			return fmt.Errorf("failed to set client read-only: %w", err)
+		}
+	}
+	return nil
+}
+
+// NewGroupedSession 新建一个与 session 同组的会话并返回其名称
+// Attach 在连接后为其开启 destroy-unattached，最后一个客户端断开时自动销毁
+func (m *Manager) NewGroupedSession(session string) (string, error) {
+	output, err := tmuxOutput("new-session", "-d", "-t", session, "-P", "-F", "#{session_name}")
+	if err != nil {
+		return "", fmt.Errorf("failed to create grouped session: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// detachOtherClients 断开连接到 session 的客户端，保留 keep
+func (m *Manager) detachOtherClients(session, keep string) error {
+	clients, err := m.ListClients(session)
+	if err != nil {
+		return err
+	}
+	for _, c := range clients {
+		if c.Name == keep {
+			continue
+		}
+		if err := m.DetachClient(c.Name); err != nil {
+			return fmt.Errorf("failed to detach client %s: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// currentClient 返回当前客户端名称，不在 tmux 中时返回空字符串
+func currentClient() string {
+	if !inTmuxSession() {
+		return ""
+	}
+	output, err := tmuxOutput("display-message", "-p", "#{client_name}")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// splitTarget 将 dev:1.0 拆分为会话名和其余部分（:1.0）
+func splitTarget(target string) (session, rest string) {
+	if i := strings.Index(target, ":"); i >= 0 {
+		return target[:i], target[i:]
+	}
+	return target, ""
+}
@@ -0,0 +1,83 @@
+package tmux
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Client 表示一个连接到 tmux 服务器的客户端
+type Client struct {
+	Name     string
+	TTY      string
+	User     string
+	Width    int
+	Height   int
+	Session  string
+	Activity time.Time // 最后一次输入的时间
+	ReadOnly bool
+	Term     string
+}
+
+// Idle 返回客户端的空闲时长
+func (c Client) Idle() time.Duration {
+	if c.Activity.IsZero() {
+		return 0
+	}
+	return time.Since(c.Activity)
+}
+
+const clientFormat = "#{client_name}\t#{client_tty}\t#{client_user}\t#{client_width}\t#{client_height}\t#{client_session}\t#{client_activity}\t#{client_readonly}\t#{client_termname}"
+
+// ListClients 获取连接到 session 的客户端，session 为空时列出全部客户端
+func (m *Manager) ListClients(session string) ([]Client, error) {
+	args := []string{"list-clients", "-F", clientFormat}
+	if session != "" {
+		args = append(args, "-t", session)
+	}
+
+	output, err := tmuxOutput(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clients: %w", err)
+	}
+
+	var clients []Client
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 9 {
+			continue
+		}
+		activity, _ := parseTimestamp(parts[6])
+		clients = append(clients, Client{
+			Name:     parts[0],
+			TTY:      parts[1],
+			User:     parts[2],
+			Width:    parseInt(parts[3]),
+			Height:   parseInt(parts[4]),
+			Session:  parts[5],
+			Activity: activity,
+			ReadOnly: parts[7] == "1",
+			Term:     parts[8],
+		})
+	}
+	return clients, nil
+}
+
+// DetachClient 断开指定的客户端
+func (m *Manager) DetachClient(name string) error {
+	return run("detach-client", "-t", name)
+}
+
+// SwitchClient 将指定客户端切换到会话，client 为空时使用当前客户端
+func (m *Manager) SwitchClient(client, name string) error {
+	args := []string{"switch-client", "-t", name}
+	if client != "" {
+		args = append(args, "-c", client)
+	}
+	return run(args...)
+}
+
+// RefreshClient 重绘指定客户端的屏幕
+func (m *Manager) RefreshClient(name string) error {
+	return run("refresh-client", "-t", name)
+}
@@ -0,0 +1,45 @@
+package tmux
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// CopyToClipboard 通过 OSC 52 将内容复制到系统剪贴板，远程 SSH 会话中同样有效
+// 在 tmux 中（>= 3.2）交给 load-buffer -w，由 tmux 转发给客户端所在的终端，
+// 同时保存为 tmux 粘贴缓冲区；否则向 out 写入 OSC 52 序列，out 为空时写入 /dev/tty
+// 终端由其他程序（例如 tmx 界面）接管时，out 应为该程序的输出
+func (m *Manager) CopyToClipboard(content string, out io.Writer) error {
+	if inTmuxSession() {
+		if v, err := m.Version(); err == nil && v.AtLeast(3, 2) {
+			cmd := exec.Command("tmux", "load-buffer", "-w", "-")
+			cmd.Stdin = strings.NewReader(content)
+			if output, err := cmd.CombinedOutput(); err != nil {
+				return fmt.Errorf("failed to copy to clipboard: %s", strings.TrimSpace(string(output)))
+			}
+			return nil
+		}
+	}
+
+	seq := osc52.New(content)
+	if inTmuxSession() {
+		seq = seq.Tmux() // 旧版本 tmux 需要 passthrough
+	}
+
+	if out == nil {
+		out = os.Stderr
+		if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+			defer tty.Close()
+			out = tty
+		}
+	}
+	if _, err := seq.WriteTo(out); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+	return nil
+}
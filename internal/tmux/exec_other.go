@@ -0,0 +1,17 @@
+//go:build !unix
+
+package tmux
+
+import (
+	"os"
+	"os/exec"
+)
+
+// execTmux 在不支持 exec 的平台上以子进程运行 tmux 客户端
+func execTmux(args ...string) error {
+	cmd := exec.Command("tmux", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// execTmux 用 tmux 客户端替换当前进程，终端直接交给 tmux
+// 成功时不会返回
+func execTmux(args ...string) error {
+	path, err := exec.LookPath("tmux")
+	if err != nil {
+		return fmt.Errorf("tmux not found: %w", err)
+	}
+	return syscall.Exec(path, append([]string{"tmux"}, args...), os.Environ())
+}
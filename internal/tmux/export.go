@@ -0,0 +1,80 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CaptureScrollback 读取目标的全部滚动历史，目标可以是窗格、窗口或会话
+// 包含多个窗格时，每个窗格前加上 ==> 目标 <== 标题
+// escapes 为 true 时保留颜色等 ANSI 转义序列
+func (m *Manager) CaptureScrollback(target string, escapes bool) (string, error) {
+	panes, err := m.ResolvePanes([]string{target})
+	if err != nil {
+		return "", err
+	}
+	if len(panes) == 0 {
+		return "", fmt.Errorf("no panes match %s", target)
+	}
+
+	var b strings.Builder
+	for i, p := range panes {
+		args := []string{"capture-pane", "-p", "-J", "-t", p.ID, "-S", "-"}
+		if escapes {
+			args = append(args, "-e")
+		}
+		output, err := tmuxOutput(args...)
+		if err != nil {
+			return "", fmt.Errorf("failed to capture %s: %w", p.Target(), err)
+		}
+
+		if len(panes) > 1 {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "==> %s (%s) <==\n", p.Target(), p.Command)
+		}
+		// 去掉可见区域末尾的空行
+		b.WriteString(strings.TrimRight(string(output), "\n"))
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// ExportScrollback 将目标的滚动历史保存到 dir 下的新文件，返回文件路径
+// 文件名由目标和时间组成，例如 dev_1.0-20250101-150405.log，
+// 同一秒内多次导出时依次加上 -2、-3 等后缀，不会覆盖已有文件
+func (m *Manager) ExportScrollback(target, dir string, escapes bool) (string, error) {
+	content, err := m.CaptureScrollback(target, escapes)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	name := strings.NewReplacer(":", "_", "/", "_", "*", "_", "?", "_").Replace(target)
+	base := filepath.Join(dir, fmt.Sprintf("%s-%s", name, time.Now().Format("20060102-150405")))
+	path := base + ".log"
+	for i := 2; ; i++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if os.IsExist(err) {
+			path = fmt.Sprintf("%s-%d.log", base, i)
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		_, err = f.WriteString(content)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		return path, nil
+	}
+}
@@ -0,0 +1,38 @@
+package tmux
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestExportScrollbackKeepsEarlierFiles(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	// 使用临时目录中的 socket，避免读写用户正在运行的 tmux
+	t.Setenv("TMUX", "")
+	t.Setenv("TMUX_TMPDIR", t.TempDir())
+	t.Cleanup(func() { exec.Command("tmux", "kill-server").Run() })
+	if output, err := exec.Command("tmux", "new-session", "-d", "-s", "dev").CombinedOutput(); err != nil {
+		t.Fatalf("new-session: %v: %s", err, output)
+	}
+
+	m := NewManager()
+	dir := t.TempDir()
+	// 同一秒内的导出使用相同的时间戳，不能覆盖前一个文件
+	seen := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		path, err := m.ExportScrollback("dev", dir, false)
+		if err != nil {
+			t.Fatalf("ExportScrollback: %v", err)
+		}
+		if seen[path] {
+			t.Fatalf("export %d overwrote %s", i+1, path)
+		}
+		seen[path] = true
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 3 {
+		t.Errorf("got %d files, want 3", len(entries))
+	}
+}
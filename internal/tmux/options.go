@@ -0,0 +1,21 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GlobalOption 读取全局会话选项的值（show-options -gv）
+func (m *Manager) GlobalOption(name string) (string, error) {
+	output, err := tmuxOutput("show-options", "-gv", name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read option %s: %w", name, err)
+	}
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+// SetGlobalOption 设置全局会话选项
+func (m *Manager) SetGlobalOption(name, value string) error {
+	return exec.Command("tmux", "set-option", "-g", name, value).Run()
+}
@@ -0,0 +1,156 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Pane 表示一个 tmux 窗格
+type Pane struct {
+	ID         string // 全局唯一的窗格 ID，例如 %3
+	Session    string
+	Window     int
+	Index      int
+	Command    string // 当前运行的命令
+	Path       string // 当前工作目录
+	Active     bool
+	Width      int
+	Height     int
+	Dead       bool   // 命令已退出但窗格保留（remain-on-exit）
+	Recording  string // 正在录制时为录制文件路径；只有录制选项而没有输出管道时视为未录制
+	PID        int    // 窗格中最初启动的进程（通常是 shell）
+	DeadStatus int    // 已退出窗格的退出码
+	Watching   bool   // 正在等待前台命令结束（tmx watch）
+	Finished   string // 最近一次等待到的命令结束记录，由 watch 包解析
+}
+
+// Target 返回窗格的 tmux 目标，例如 dev:1.0
+func (p Pane) Target() string {
+	return fmt.Sprintf("%s:%d.%d", p.Session, p.Window, p.Index)
+}
+
+const paneFormat = "#{pane_id}\t#{session_name}\t#{window_index}\t#{pane_index}\t#{pane_current_command}\t#{pane_current_path}\t#{pane_active}\t#{pane_width}\t#{pane_height}\t#{pane_dead}\t#{?pane_pipe,#{@tmx_record},}" +
+	"\t#{pane_pid}\t#{pane_dead_status}\t#{@tmx_watch}\t#{@tmx_finished}"
+
+// ListPanes 获取会话中的全部窗格，session 为空时列出所有会话的窗格
+func (m *Manager) ListPanes(session string) ([]Pane, error) {
+	args := []string{"list-panes", "-F", paneFormat}
+	if session == "" {
+		args = append(args, "-a")
+	} else {
+		args = append(args, "-s", "-t", session)
+	}
+
+	output, err := tmuxOutput(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list panes: %w", err)
+	}
+
+	var panes []Pane
+	// 最后一列可能为空，只去掉末尾的换行
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 15 {
+			continue
+		}
+		panes = append(panes, Pane{
+			ID:         parts[0],
+			Session:    parts[1],
+			Window:     parseInt(parts[2]),
+			Index:      parseInt(parts[3]),
+			Command:    parts[4],
+			Path:       parts[5],
+			Active:     parts[6] == "1",
+			Width:      parseInt(parts[7]),
+			Height:     parseInt(parts[8]),
+			Dead:       parts[9] == "1",
+			Recording:  parts[10],
+			PID:        parseInt(parts[11]),
+			DeadStatus: parseInt(parts[12]),
+			Watching:   parts[13] != "",
+			Finished:   parts[14],
+		})
+	}
+	return panes, nil
+}
+
+// SplitPane 拆分窗格，horizontal 为 true 时左右拆分，否则上下拆分
+// command 为新窗格中运行的命令，为空时运行默认 shell
+func (m *Manager) SplitPane(target string, horizontal bool, command string) error {
+	args := []string{"split-window", "-d", "-t", target}
+	if horizontal {
+		args = append(args, "-h")
+	} else {
+		args = append(args, "-v")
+	}
+	if command != "" {
+		args = append(args, command)
+	}
+	return run(args...)
+}
+
+// KillPane 关闭窗格
+func (m *Manager) KillPane(target string) error {
+	return run("kill-pane", "-t", target)
+}
+
+// ZoomPane 切换窗格的最大化状态
+func (m *Manager) ZoomPane(target string) error {
+	return run("resize-pane", "-Z", "-t", target)
+}
+
+// RespawnPane 在已退出的窗格中重新运行原命令
+// 命令仍在运行时 tmux 会拒绝，避免误杀进程
+func (m *Manager) RespawnPane(target string) error {
+	output, err := exec.Command("tmux", "respawn-pane", "-t", target).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// BreakPane 将窗格拆出为同一会话中的独立窗口
+func (m *Manager) BreakPane(target string) error {
+	session, _ := splitTarget(target)
+	return run("break-pane", "-d", "-s", target, "-t", session+":")
+}
+
+// JoinPane 将窗格移入另一个窗口
+func (m *Manager) JoinPane(src, dst string) error {
+	return run("join-pane", "-d", "-s", src, "-t", dst)
+}
+
+// FindPane 按窗格 ID（例如 %3）查找窗格，窗格不存在时 ok 为 false
+func (m *Manager) FindPane(id string) (pane Pane, ok bool, err error) {
+	panes, err := m.ListPanes("")
+	if err != nil {
+		return Pane{}, false, err
+	}
+	for _, p := range panes {
+		if p.ID == id {
+			return p, true, nil
+		}
+	}
+	return Pane{}, false, nil
+}
+
+// SetPaneOption 设置窗格的用户选项（@ 开头），value 为空时取消设置
+func (m *Manager) SetPaneOption(target, name, value string) error {
+	args := []string{"set-option", "-p", "-t", target, name, value}
+	if value == "" {
+		args = []string{"set-option", "-p", "-u", "-t", target, name}
+	}
+	if output, err := exec.Command("tmux", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set %s on %s: %s", name, target, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RunShell 在 tmux 服务器中后台运行 shell 命令，命令不受客户端断开影响
+func (m *Manager) RunShell(command string) error {
+	if output, err := exec.Command("tmux", "run-shell", "-b", command).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to run %q: %s", command, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
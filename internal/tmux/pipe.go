@@ -0,0 +1,40 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// recordOption 是记录录制文件路径的窗格选项
+const recordOption = "@tmx_record"
+
+// StartPipe 将窗格的输出通过管道交给 command，并在窗格选项中记录 file
+// 窗格已有管道（例如其他程序的 pipe-pane）时返回错误；
+// 只有选项没有管道（录制进程异常退出）的旧记录会被覆盖
+func (m *Manager) StartPipe(target, command, file string) error {
+	output, err := tmuxOutput("display-message", "-p", "-t", target, "#{pane_pipe}")
+	if err != nil {
+		return commandError([]string{"display-message"}, err)
+	}
+	if strings.TrimSpace(string(output)) == "1" {
+		return fmt.Errorf("%s already has an output pipe", target)
+	}
+	if output, err := exec.Command("tmux", "pipe-pane", "-o", "-t", target, command).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to pipe %s: %s", target, strings.TrimSpace(string(output)))
+	}
+	return run("set-option", "-p", "-t", target, recordOption, file)
+}
+
+// StopPipe 关闭窗格的输出管道
+func (m *Manager) StopPipe(target string) error {
+	if output, err := exec.Command("tmux", "pipe-pane", "-t", target).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stop pipe on %s: %s", target, strings.TrimSpace(string(output)))
+	}
+	return run("set-option", "-p", "-u", "-t", target, recordOption)
+}
+
+// ShellQuote 用单引号包裹参数，用于拼接交给 sh 执行的命令
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,61 @@
+package tmux
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestRecordingRequiresPipe(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	t.Setenv("TMUX", "")
+	t.Setenv("TMUX_TMPDIR", t.TempDir())
+	t.Cleanup(func() { exec.Command("tmux", "kill-server").Run() })
+	if output, err := exec.Command("tmux", "new-session", "-d", "-s", "dev").CombinedOutput(); err != nil {
+		t.Fatalf("new-session: %v: %s", err, output)
+	}
+
+	m := NewManager()
+	recording := func() string {
+		t.Helper()
+		panes, err := m.ListPanes("dev")
+		if err != nil || len(panes) != 1 {
+			t.Fatalf("ListPanes: %v, %v", panes, err)
+		}
+		return panes[0].Recording
+	}
+
+	// 录制进程异常退出后只剩选项，不算正在录制，可以重新开始
+	if output, err := exec.Command("tmux", "set-option", "-p", "-t", "dev", recordOption, "/tmp/stale.log").CombinedOutput(); err != nil {
+		t.Fatalf("set-option: %v: %s", err, output)
+	}
+	if got := recording(); got != "" {
+		t.Errorf("Recording with a stale marker = %q, want empty", got)
+	}
+	if err := m.StartPipe("dev", "cat > /dev/null", "/tmp/new.log"); err != nil {
+		t.Fatalf("StartPipe over a stale marker: %v", err)
+	}
+	if got := recording(); got != "/tmp/new.log" {
+		t.Errorf("Recording = %q, want /tmp/new.log", got)
+	}
+	if err := m.StopPipe("dev"); err != nil {
+		t.Fatalf("StopPipe: %v", err)
+	}
+	if got := recording(); got != "" {
+		t.Errorf("Recording after StopPipe = %q, want empty", got)
+	}
+
+	// 其他程序的管道不是录制，也不能被当作录制开始
+	if output, err := exec.Command("tmux", "pipe-pane", "-t", "dev", "cat > /dev/null").CombinedOutput(); err != nil {
+		t.Fatalf("pipe-pane: %v: %s", err, output)
+	}
+	err := m.StartPipe("dev", "cat > /dev/null", "/tmp/other.log")
+	if err == nil || !strings.Contains(err.Error(), "already has an output pipe") {
+		t.Errorf("StartPipe over another pipe: %v", err)
+	}
+	if got := recording(); got != "" {
+		t.Errorf("Recording with another pipe = %q, want empty", got)
+	}
+}
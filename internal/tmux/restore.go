@@ -0,0 +1,103 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// 以下创建会话、窗口和窗格的函数返回新窗格的 ID（例如 %12），
+// 不受 base-index 和 pane-base-index 影响，也可以作为窗口目标使用
+
+// CreateSession 在后台新建会话，第一个窗口的名称、工作目录和运行的命令由参数指定
+// width 和 height 大于 0 时作为会话中窗口的大小，否则使用 tmux 的默认大小（80x24）
+func (m *Manager) CreateSession(name, window, dir, command string, width, height int) (string, error) {
+	args := []string{"new-session", "-d", "-P", "-F", "#{pane_id}", "-s", name}
+	if width > 0 && height > 0 {
+		args = append(args, "-x", strconv.Itoa(width), "-y", strconv.Itoa(height))
+	}
+	return create(append(args, createArgs(window, dir, command)...))
+}
+
+// CreateWindow 在会话的 index 位置新建窗口，该位置已有窗口时失败
+func (m *Manager) CreateWindow(session string, index int, name, dir, command string) (string, error) {
+	args := []string{"new-window", "-d", "-P", "-F", "#{pane_id}", "-t", fmt.Sprintf("%s:%d", session, index)}
+	return create(append(args, createArgs(name, dir, command)...))
+}
+
+// MoveWindowIndex 将 target 所在的窗口移到所在会话的 index 位置，已在该位置时不做改变
+func (m *Manager) MoveWindowIndex(target string, index int) error {
+	args := []string{"display-message", "-p", "-t", target, "#{session_name}\t#{window_index}"}
+	output, err := tmuxOutput(args...)
+	if err != nil {
+		return commandError(args, err)
+	}
+	session, current, _ := strings.Cut(strings.TrimSpace(string(output)), "\t")
+	if parseInt(current) == index {
+		return nil
+	}
+	return run("move-window", "-d", "-s", target, "-t", fmt.Sprintf("%s:%d", session, index))
+}
+
+// CreatePane 拆分 target 所在的窗口，在新窗格中以 dir 为工作目录运行 command
+func (m *Manager) CreatePane(target, dir, command string) (string, error) {
+	args := []string{"split-window", "-d", "-P", "-F", "#{pane_id}", "-t", target}
+	return create(append(args, createArgs("", dir, command)...))
+}
+
+// SelectLayout 对 target 所在的窗口应用 window_layout 格式的布局，窗格数量需要与布局一致
+func (m *Manager) SelectLayout(target, layout string) error {
+	return run("select-layout", "-t", target, layout)
+}
+
+// SelectPane 将窗格设为所在窗口的当前窗格
+func (m *Manager) SelectPane(target string) error {
+	return run("select-pane", "-t", target)
+}
+
+// SelectWindow 将 target 所在的窗口设为会话的当前窗口
+func (m *Manager) SelectWindow(target string) error {
+	return run("select-window", "-t", target)
+}
+
+func createArgs(name, dir, command string) []string {
+	var args []string
+	if name != "" {
+		args = append(args, "-n", name)
+	}
+	if dir != "" {
+		args = append(args, "-c", dir)
+	}
+	if command != "" {
+		args = append(args, command)
+	}
+	return args
+}
+
+// create 运行新建会话、窗口或窗格的命令，返回输出的窗格 ID
+func create(args []string) (string, error) {
+	output, err := exec.Command("tmux", args...).Output()
+	if err != nil {
+		return "", commandError(args, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// run 运行 tmux 命令，失败时返回 tmux 的错误信息
+func run(args ...string) error {
+	if output, err := exec.Command("tmux", args...).CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("%s: %s", args[0], msg)
+		}
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	return nil
+}
+
+func commandError(args []string, err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return fmt.Errorf("%s: %w", args[0], err)
+}
@@ -0,0 +1,119 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Match 是滚动历史中的一处匹配
+type Match struct {
+	Pane    Pane
+	Line    int    // 行号，从滚动历史最早的一行开始，从 1 计数
+	FromEnd int    // 距离最后一行的行数，用于在复制模式中定位
+	Text    string // 匹配的行
+	Before  []string
+	After   []string
+}
+
+// SearchOptions 控制滚动历史的搜索
+type SearchOptions struct {
+	Context  int    // 匹配行前后显示的行数
+	MaxLines int    // 每个窗格最多读取的历史行数，0 表示读取全部
+	Exclude  string // 跳过的窗格 ID，例如运行搜索本身的窗格
+}
+
+const (
+	// DefaultSearchLines 是每个窗格默认读取的历史行数上限
+	DefaultSearchLines = 50000
+	// searchWorkers 是同时读取窗格内容的数量
+	searchWorkers = 8
+)
+
+// SearchPanes 在服务器上所有窗格的滚动历史中搜索 re
+// 窗格并发读取，结果按窗格顺序和行号排列
+func (m *Manager) SearchPanes(re *regexp.Regexp, opts SearchOptions) ([]Match, error) {
+	panes, err := m.ListPanes("")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]Match, len(panes))
+	sem := make(chan struct{}, searchWorkers)
+	var wg sync.WaitGroup
+	for i, p := range panes {
+		if p.ID == opts.Exclude {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, p Pane) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			content, err := m.CapturePane(p.ID, opts.MaxLines)
+			if err != nil {
+				return // 窗格可能在搜索期间关闭
+			}
+			results[i] = searchLines(p, strings.Split(content, "\n"), re, opts.Context)
+		}(i, p)
+	}
+	wg.Wait()
+
+	var matches []Match
+	for _, r := range results {
+		matches = append(matches, r...)
+	}
+	return matches, nil
+}
+
+// CapturePane 返回窗格的滚动历史和可见内容，maxLines 为 0 时读取全部历史
+func (m *Manager) CapturePane(target string, maxLines int) (string, error) {
+	start := "-"
+	if maxLines > 0 {
+		start = "-" + strconv.Itoa(maxLines)
+	}
+	output, err := tmuxOutput("capture-pane", "-p", "-t", target, "-S", start)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture %s: %w", target, err)
+	}
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+// ShowMatch 让窗格进入复制模式，并滚动到匹配所在的位置（尽量居中）
+func (m *Manager) ShowMatch(match Match) error {
+	target := match.Pane.ID
+	if err := exec.Command("tmux", "copy-mode", "-t", target).Run(); err != nil {
+		return fmt.Errorf("failed to enter copy mode in %s: %w", target, err)
+	}
+	// goto-line 的参数是从可见区域顶部向上滚动的行数
+	offset := match.FromEnd - match.Pane.Height + 1 + match.Pane.Height/2
+	if offset <= 0 {
+		return nil
+	}
+	if err := exec.Command("tmux", "send-keys", "-t", target, "-X", "goto-line", strconv.Itoa(offset)).Run(); err != nil {
+		return fmt.Errorf("failed to scroll %s: %w", target, err)
+	}
+	return nil
+}
+
+func searchLines(p Pane, lines []string, re *regexp.Regexp, context int) []Match {
+	var matches []Match
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		matches = append(matches, Match{
+			Pane:    p,
+			Line:    i + 1,
+			FromEnd: len(lines) - 1 - i,
+			Text:    line,
+			Before:  lines[max(i-context, 0):i],
+			After:   lines[i+1 : min(i+1+context, len(lines))],
+		})
+	}
+	return matches
+}
@@ -0,0 +1,73 @@
+package tmux
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// SendCommand 将命令输入到窗格并回车
+// 命令按字面发送，不会被解释为 tmux 按键名，以 - 开头也不会被当作选项
+func (m *Manager) SendCommand(target, command string) error {
+	if err := run("send-keys", "-t", target, "-l", "--", command); err != nil {
+		return fmt.Errorf("failed to send to %s: %w", target, err)
+	}
+	if err := run("send-keys", "-t", target, "Enter"); err != nil {
+		return fmt.Errorf("failed to send to %s: %w", target, err)
+	}
+	return nil
+}
+
+// SendKeys 向窗格发送按键，keys 使用 tmux 按键名，例如 C-c、Enter
+func (m *Manager) SendKeys(target string, keys ...string) error {
+	args := append([]string{"send-keys", "-t", target, "--"}, keys...)
+	if err := run(args...); err != nil {
+		return fmt.Errorf("failed to send to %s: %w", target, err)
+	}
+	return nil
+}
+
+// ResolvePanes 返回与 patterns 匹配的窗格，按出现顺序去重
+// 每个 pattern 的形式为 会话[:窗口[.窗格]]，会话名支持通配符（例如 web-*）
+// 只写会话时匹配会话中的全部窗格，写到窗口时匹配窗口中的全部窗格
+func (m *Manager) ResolvePanes(patterns []string) ([]Pane, error) {
+	panes, err := m.ListPanes("")
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []Pane
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		for _, p := range panes {
+			if !seen[p.ID] && matchPane(pattern, p) {
+				seen[p.ID] = true
+				matched = append(matched, p)
+			}
+		}
+	}
+	return matched, nil
+}
+
+// matchPane 判断窗格是否与 会话[:窗口[.窗格]] 形式的 pattern 匹配
+func matchPane(pattern string, p Pane) bool {
+	session, rest := splitTarget(pattern)
+	if ok, _ := path.Match(session, p.Session); !ok {
+		return false
+	}
+	rest = strings.TrimPrefix(rest, ":")
+	if rest == "" {
+		return true
+	}
+
+	window, pane, hasPane := strings.Cut(rest, ".")
+	if index, err := strconv.Atoi(window); err != nil || index != p.Window {
+		return false
+	}
+	if !hasPane {
+		return true
+	}
+	index, err := strconv.Atoi(pane)
+	return err == nil && index == p.Index
+}
@@ -0,0 +1,58 @@
+package tmux
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSendCommandStartingWithDash(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	t.Setenv("TMUX", "")
+	t.Setenv("TMUX_TMPDIR", t.TempDir())
+	t.Cleanup(func() { exec.Command("tmux", "kill-server").Run() })
+	if output, err := exec.Command("tmux", "new-session", "-d", "-s", "dev", "cat").CombinedOutput(); err != nil {
+		t.Fatalf("new-session: %v: %s", err, output)
+	}
+
+	m := NewManager()
+	if err := m.SendCommand("dev", "-n hello"); err != nil {
+		t.Fatalf("SendCommand: %v", err)
+	}
+	if err := m.SendKeys("dev", "-", "x", "Enter"); err != nil {
+		t.Fatalf("SendKeys: %v", err)
+	}
+
+	want := []string{"-n hello", "-x"}
+	var got string
+	for i := 0; i < 50; i++ {
+		output, _ := exec.Command("tmux", "capture-pane", "-p", "-t", "dev").Output()
+		got = string(output)
+		if strings.Contains(got, want[0]) && strings.Contains(got, want[1]) {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Errorf("pane content = %q, want lines %q", got, want)
+}
+
+func TestSendCommandReportsTmuxError(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	t.Setenv("TMUX", "")
+	t.Setenv("TMUX_TMPDIR", t.TempDir())
+	t.Cleanup(func() { exec.Command("tmux", "kill-server").Run() })
+	if output, err := exec.Command("tmux", "new-session", "-d", "-s", "dev").CombinedOutput(); err != nil {
+		t.Fatalf("new-session: %v: %s", err, output)
+	}
+
+	// 错误信息应包含 tmux 的 stderr，而不只是退出码
+	err := NewManager().SendCommand("missing", "ls")
+	if err == nil || !strings.Contains(err.Error(), "can't find") {
+		t.Errorf("SendCommand to missing target: %v, want tmux's error message", err)
+	}
+}
@@ -0,0 +1,49 @@
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// SocketPath 返回当前 tmux 服务器的 socket 路径
+// 在 tmux 中取 $TMUX 的第一段，否则按 tmux 的默认规则推算
+func SocketPath() string {
+	if env := os.Getenv("TMUX"); env != "" {
+		return strings.SplitN(env, ",", 2)[0]
+	}
+	dir := os.Getenv("TMUX_TMPDIR")
+	if dir == "" {
+		dir = "/tmp"
+	}
+	return filepath.Join(dir, fmt.Sprintf("tmux-%d", os.Getuid()), "default")
+}
+
+// ServerInfo 查询运行中 tmux 服务器的 socket 路径和进程号
+func (m *Manager) ServerInfo() (socket string, pid int, err error) {
+	output, err := exec.Command("tmux", "display-message", "-p", "#{socket_path} #{pid}").Output()
+	if err != nil {
+		// 不在客户端中时 display-message 需要目标会话，改用 list-sessions
+		output, err = exec.Command("tmux", "list-sessions", "-F", "#{socket_path} #{pid}").Output()
+		if err != nil {
+			return "", 0, fmt.Errorf("tmux server not reachable: %w", err)
+		}
+	}
+	line := strings.SplitN(strings.TrimSpace(string(output)), "\n", 2)[0]
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return "", 0, fmt.Errorf("unexpected tmux output %q", line)
+	}
+	return fields[0], parseInt(fields[1]), nil
+}
+
+// KeyBinding 返回指定按键表中某个按键的绑定，未绑定时返回空字符串
+func (m *Manager) KeyBinding(table, key string) string {
+	output, err := exec.Command("tmux", "list-keys", "-T", table, key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -10,11 +10,35 @@ import (
 
 // Session 表示一个 tmux 会话
 type Session struct {
-	Name      string
-	Created   time.Time
-	Active    bool
-	Windows   int
-	Attached  bool
+	Name     string
+	Created  time.Time
+	Active   bool
+	Windows  int
+	Attached bool
+	Alerts   Alerts // 会话中各窗口提醒标记的汇总
+	Path     string // 会话的工作目录（session_path）
+	// Activity 是客户端最近一次输入或连接的时间（session_activity，窗格输出不会更新它）
+	// Output 是会话中窗口最近一次有输出的时间（各窗口 window_activity 的最大值）
+	// LastAttached 是最近一次被连接的时间
+	Activity     time.Time
+	Output       time.Time
+	LastAttached time.Time
+	Pinned       bool // 标记为保留，不会被 tmx reap 清理
+}
+
+// pinnedOption 是标记会话保留的会话选项
+const pinnedOption = "@tmx_pinned"
+
+// Idle 返回会话空闲的时长：距离最近一次窗口输出、客户端输入或连接的时间
+// 没人输入但仍在输出（例如长时间的构建、写日志的服务）的会话不算空闲
+func (s Session) Idle() time.Duration {
+	last := s.Created
+	for _, t := range []time.Time{s.Activity, s.Output, s.LastAttached} {
+		if t.After(last) {
+			last = t
+		}
+	}
+	return time.Since(last)
 }
 
 // Manager 管理 tmux 会话
@@ -27,8 +51,8 @@ func NewManager() *Manager {
 
 // ListSessions 获取所有 tmux 会话
 func (m *Manager) ListSessions() ([]Session, error) {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}:#{session_created}:#{session_windows}:#{session_attached}")
-	output, err := cmd.Output()
+	output, err := tmuxOutput("list-sessions", "-F", "#{session_name}:#{session_created}:#{session_windows}:#{session_attached}:#{session_alerts}:"+
+		"#{session_activity}:#{session_last_attached}:#{@tmx_pinned}:#{session_path}")
 	if err != nil {
 		// 如果 tmux 没有运行或没有会话
 		if exitError, ok := err.(*exec.ExitError); ok {
@@ -41,13 +65,14 @@ func (m *Manager) ListSessions() ([]Session, error) {
 
 	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
 	sessions := make([]Session, 0, len(lines))
+	windowOutput := lastWindowOutput()
 
 	for _, line := range lines {
 		if line == "" {
 			continue
 		}
 		parts := strings.Split(line, ":")
-		if len(parts) < 4 {
+		if len(parts) < 9 {
 			continue
 		}
 
@@ -63,18 +88,46 @@ func (m *Manager) ListSessions() ([]Session, error) {
 		} else {
 			created = time.Now() // 如果解析失败，使用当前时间
 		}
+		// 从未连接过的会话 session_last_attached 为空，解析失败时为零值
+		activity, _ := parseTimestamp(parts[5])
+		lastAttached, _ := parseTimestamp(parts[6])
 
 		sessions = append(sessions, Session{
-			Name:     name,
-			Created:  created,
-			Windows:  parseInt(windows),
-			Attached: parseInt(attached) > 0,
+			Name:         name,
+			Created:      created,
+			Windows:      parseInt(windows),
+			Attached:     parseInt(attached) > 0,
+			Alerts:       parseSessionAlerts(parts[4]),
+			Path:         strings.Join(parts[8:], ":"), // 路径中可能包含冒号
+			Activity:     activity,
+			Output:       windowOutput[name],
+			LastAttached: lastAttached,
+			Pinned:       parts[7] != "",
 		})
 	}
 
 	return sessions, nil
 }
 
+// lastWindowOutput 返回每个会话中窗口最近一次有输出的时间
+func lastWindowOutput() map[string]time.Time {
+	last := make(map[string]time.Time)
+	output, err := tmuxOutput("list-windows", "-a", "-F", "#{window_activity}:#{session_name}")
+	if err != nil {
+		return last
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		activity, name, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if t, err := parseTimestamp(activity); err == nil && t.After(last[name]) {
+			last[name] = t
+		}
+	}
+	return last
+}
+
 // AttachSession 连接到指定的会话
 func (m *Manager) AttachSession(name string) error {
 	// 检查当前是否在 tmux 会话中
@@ -84,9 +137,8 @@ func (m *Manager) AttachSession(name string) error {
 		return cmd.Run()
 	}
 
-	// 不在 tmux 中，使用 attach-session
-	cmd := exec.Command("tmux", "attach-session", "-t", name)
-	return cmd.Run()
+	// 不在 tmux 中，用 attach-session 接管当前终端
+	return execTmux("attach-session", "-t", name)
 }
 
 // DetachSession 断开指定的会话
@@ -102,9 +154,27 @@ func (m *Manager) NewSession(name string) error {
 }
 
 // NewSessionAndAttach 创建新会话并立即进入
+// 在 tmux 外运行时按需启动 tmux 服务器并接管当前终端，会话已存在时直接进入
 func (m *Manager) NewSessionAndAttach(name string) error {
-	cmd := exec.Command("tmux", "new-session", "-s", name)
-	return cmd.Run()
+	if inTmuxSession() {
+		if err := m.NewSession(name); err != nil {
+			return err
+		}
+		return m.AttachSession(name)
+	}
+	return execTmux("new-session", "-A", "-s", name)
+}
+
+// SetPinned 标记或取消标记会话为保留
+func (m *Manager) SetPinned(name string, pinned bool) error {
+	args := []string{"set-option", "-t", name, pinnedOption, "1"}
+	if !pinned {
+		args = []string{"set-option", "-u", "-t", name, pinnedOption}
+	}
+	if err := exec.Command("tmux", args...).Run(); err != nil {
+		return fmt.Errorf("failed to set %s on %s: %w", pinnedOption, name, err)
+	}
+	return nil
 }
 
 // KillSession 删除指定的会话
@@ -113,6 +183,18 @@ func (m *Manager) KillSession(name string) error {
 	return cmd.Run()
 }
 
+// CurrentSession 返回当前客户端所在的会话名称，不在 tmux 中时返回空字符串
+func (m *Manager) CurrentSession() string {
+	if !inTmuxSession() {
+		return ""
+	}
+	output, err := tmuxOutput("display-message", "-p", "#{session_name}")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
 // IsTmuxRunning 检查 tmux 是否在运行
 func (m *Manager) IsTmuxRunning() bool {
 	// 检查是否有 tmux 会话存在
@@ -131,6 +213,12 @@ func inTmuxSession() bool {
 
 // 辅助函数
 
+// tmuxOutput 运行 tmux 命令并返回输出
+// -u 强制 UTF-8 输出，否则在非 UTF-8 的 locale 下 tmux 会把制表符和中文替换为 _
+func tmuxOutput(args ...string) ([]byte, error) {
+	return exec.Command("tmux", append([]string{"-u"}, args...)...).Output()
+}
+
 func parseTimestamp(ts string) (time.Time, error) {
 	// tmux 时间戳可能是秒或微秒
 	var sec int64
@@ -0,0 +1,52 @@
+package tmux
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version 表示 tmux 版本号，例如 "tmux 3.3a" 解析为 3.3
+type Version struct {
+	Major int
+	Minor int
+	Raw   string
+}
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// ParseVersion 解析 tmux -V 的输出（tmux 3.3a、tmux next-3.4、tmux 3.2-rc3 等）
+func ParseVersion(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+	match := versionPattern.FindStringSubmatch(s)
+	if match == nil {
+		return Version{}, fmt.Errorf("unrecognized tmux version %q", s)
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return Version{Major: major, Minor: minor, Raw: s}, nil
+}
+
+// AtLeast 检查版本是否不低于 major.minor
+func (v Version) AtLeast(major, minor int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+// String 返回版本号，例如 3.3
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// Version 获取已安装 tmux 的版本
+func (m *Manager) Version() (Version, error) {
+	output, err := exec.Command("tmux", "-V").Output()
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to run tmux -V: %w", err)
+	}
+	return ParseVersion(string(output))
+}
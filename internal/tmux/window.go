@@ -0,0 +1,126 @@
+package tmux
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Window 表示一个 tmux 窗口
+type Window struct {
+	Session string
+	Index   int
+	Name    string
+	Active  bool
+	Panes   int
+	// Synchronized 窗口是否开启了 synchronize-panes
+	Synchronized bool
+	Alerts       Alerts
+	// MonitorActivity 和 MonitorSilence 是窗口的 monitor-activity 和 monitor-silence（秒）
+	MonitorActivity bool
+	MonitorSilence  int
+	// Layout 是窗口的布局描述，可以用 select-layout 恢复
+	Layout string
+	// Width 和 Height 是窗口的列数和行数
+	Width  int
+	Height int
+}
+
+// Target 返回窗口的 tmux 目标，例如 dev:1
+func (w Window) Target() string {
+	return fmt.Sprintf("%s:%d", w.Session, w.Index)
+}
+
+// windowFormat 是 list-windows 的输出格式，用制表符分隔以允许名称中包含冒号
+const windowFormat = "#{session_name}\t#{window_index}\t#{window_name}\t#{window_active}\t#{window_panes}\t#{synchronize-panes}" +
+	"\t#{window_activity_flag}\t#{window_bell_flag}\t#{window_silence_flag}\t#{monitor-activity}\t#{monitor-silence}\t#{window_layout}" +
+	"\t#{window_width}\t#{window_height}"
+
+// ListWindows 获取会话中的窗口，session 为空时列出所有会话的窗口
+func (m *Manager) ListWindows(session string) ([]Window, error) {
+	args := []string{"list-windows", "-F", windowFormat}
+	if session == "" {
+		args = append(args, "-a")
+	} else {
+		args = append(args, "-t", session)
+	}
+
+	output, err := tmuxOutput(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list windows: %w", err)
+	}
+
+	var windows []Window
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 14 {
+			continue
+		}
+		windows = append(windows, Window{
+			Session:      parts[0],
+			Index:        parseInt(parts[1]),
+			Name:         parts[2],
+			Active:       parts[3] == "1",
+			Panes:        parseInt(parts[4]),
+			Synchronized: parts[5] == "1",
+			Alerts: Alerts{
+				Activity: parts[6] == "1",
+				Bell:     parts[7] == "1",
+				Silence:  parts[8] == "1",
+			},
+			MonitorActivity: parts[9] == "1",
+			MonitorSilence:  parseInt(parts[10]),
+			Layout:          parts[11],
+			Width:           parseInt(parts[12]),
+			Height:          parseInt(parts[13]),
+		})
+	}
+	return windows, nil
+}
+
+// NewWindow 在会话末尾新建窗口，name 为空时由 tmux 命名
+func (m *Manager) NewWindow(session, name string) error {
+	args := []string{"new-window", "-d", "-t", session + ":"}
+	if name != "" {
+		args = append(args, "-n", name)
+	}
+	return run(args...)
+}
+
+// RenameWindow 重命名窗口
+func (m *Manager) RenameWindow(target, name string) error {
+	return run("rename-window", "-t", target, name)
+}
+
+// KillWindow 关闭窗口及其中的全部窗格
+func (m *Manager) KillWindow(target string) error {
+	return run("kill-window", "-t", target)
+}
+
+// SwapWindow 交换两个窗口的位置，不改变当前窗口
+func (m *Manager) SwapWindow(src, dst string) error {
+	return run("swap-window", "-d", "-s", src, "-t", dst)
+}
+
+// MoveWindow 将窗口移动到 dst，dst 可以是 会话: 或 会话:索引
+func (m *Manager) MoveWindow(src, dst string) error {
+	return run("move-window", "-d", "-s", src, "-t", dst)
+}
+
+// LinkWindow 将窗口链接到另一个会话，两个会话共享同一个窗口
+func (m *Manager) LinkWindow(src, session string) error {
+	return run("link-window", "-d", "-s", src, "-t", session+":")
+}
+
+// SetSynchronize 开启或关闭窗口的 synchronize-panes，开启后输入同时发送到所有窗格
+func (m *Manager) SetSynchronize(target string, on bool) error {
+	value := "off"
+	if on {
+		value = "on"
+	}
+	return run("set-window-option", "-t", target, "synchronize-panes", value)
+}
+
+// RenumberWindows 按顺序重新编号会话中的窗口，消除索引空洞
+func (m *Manager) RenumberWindows(session string) error {
+	return run("move-window", "-r", "-t", session)
+}
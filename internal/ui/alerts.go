@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/charmbracelet/bubbletea"
+)
+
+// refreshInterval 是会话列表和窗口视图自动刷新的间隔，用于更新提醒标记
+const refreshInterval = 2 * time.Second
+
+type refreshMsg time.Time
+
+func refreshTicker() tea.Cmd {
+	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg { return refreshMsg(t) })
+}
+
+// refresh 重新加载当前视图，输入和选择时不刷新
+func (m Model) refresh() tea.Cmd {
+	if m.inputMode || m.picker != nil || m.sendPanes != nil {
+		return nil
+	}
+	switch m.view {
+	case viewSessions:
+		return m.loadSessions()
+	case viewWindows:
+		return tea.Batch(m.loadTree(m.treeSession), m.loadUsage())
+	case viewProcesses:
+		return m.loadProcesses()
+	case viewStale:
+		return m.loadStale()
+	}
+	return nil
+}
+
+// alertBadges 返回提醒标记，例如 [bell] [silent]
+func alertBadges(a tmux.Alerts) string {
+	var badges []string
+	if a.Bell {
+		badges = append(badges, i18n.T("tui.alert_bell"))
+	}
+	if a.Activity {
+		badges = append(badges, i18n.T("tui.alert_activity"))
+	}
+	if a.Silence {
+		badges = append(badges, i18n.T("tui.alert_silence"))
+	}
+	return strings.Join(badges, " ")
+}
+
+// handleMonitorKey 处理窗口视图中的监控按键：a 开关活动监控，M 设置静默监控
+func (m Model) handleMonitorKey(key string) (tea.Model, tea.Cmd, bool) {
+	w := m.tree[m.treeSelected].window
+	focus := m.tree[m.treeSelected].target()
+
+	switch key {
+	case "a":
+		return m, treeAction(focus, func() error {
+			return m.manager.SetMonitorActivity(w.Target(), !w.MonitorActivity)
+		}), true
+
+	case "M":
+		return m.startInput(i18n.T("tui.silence_title"), i18n.T("tui.silence_prompt"), strconv.Itoa(w.MonitorSilence),
+			func(value string) tea.Cmd {
+				return treeAction(focus, func() error {
+					seconds, err := strconv.Atoi(value)
+					if err != nil || seconds < 0 {
+						return fmt.Errorf("invalid number of seconds %q", value)
+					}
+					return m.manager.SetMonitorSilence(w.Target(), seconds)
+				})
+			}), nil, true
+	}
+	return m, nil, false
+}
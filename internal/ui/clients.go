@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type clientsLoadedMsg []tmux.Client
+type clientActionMsg struct{ err error }
+
+func (m Model) loadClients() tea.Cmd {
+	return func() tea.Msg {
+		clients, err := m.manager.ListClients("")
+		if err != nil {
+			return clientsLoadedMsg{}
+		}
+		return clientsLoadedMsg(clients)
+	}
+}
+
+// clientAction 对选中的客户端执行操作，完成后刷新列表
+func (m Model) clientAction(action func(tmux.Client) error) tea.Cmd {
+	if m.clientSelected >= len(m.clients) {
+		return nil
+	}
+	client := m.clients[m.clientSelected]
+	return func() tea.Msg {
+		return clientActionMsg{action(client)}
+	}
+}
+
+// handleClients 处理客户端视图的按键
+func (m Model) handleClients(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.quitting = true
+		return m, tea.Quit
+
+	case "esc", "c", "backspace":
+		m.view = viewSessions
+		m.status = ""
+		return m, m.loadSessions()
+
+	case "up", "k":
+		if m.clientSelected > 0 {
+			m.clientSelected--
+		}
+
+	case "down", "j":
+		if m.clientSelected < len(m.clients)-1 {
+			m.clientSelected++
+		}
+
+	case "d":
+		return m, m.clientAction(func(c tmux.Client) error {
+			return m.manager.DetachClient(c.Name)
+		})
+
+	case "r":
+		return m, m.clientAction(func(c tmux.Client) error {
+			return m.manager.RefreshClient(c.Name)
+		})
+
+	case "s":
+		if m.clientSelected < len(m.clients) {
+			c := m.clients[m.clientSelected]
+			return m.startPicker(i18n.T("tui.switch_prompt", c.TTY), m.sessionNames(""), c.Session,
+				func(session string) tea.Cmd {
+					return m.clientAction(func(c tmux.Client) error {
+						return m.manager.SwitchClient(c.Name, session)
+					})
+				}), nil
+		}
+	}
+	return m, nil
+}
+
+// renderClients 渲染客户端视图
+func (m Model) renderClients() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(i18n.T("tui.clients_title")))
+	b.WriteString("\n\n")
+
+	if len(m.clients) == 0 {
+		b.WriteString(itemStyle.Render(i18n.T("tui.no_clients")))
+		b.WriteString("\n")
+	} else {
+		header := "  " + columns(
+			i18n.T("tui.client_tty"), i18n.T("tui.client_user"), i18n.T("tui.client_size"),
+			i18n.T("tui.client_session"), i18n.T("tui.client_idle"))
+		b.WriteString(hintStyle.Render(header))
+		b.WriteString("\n")
+
+		for i, c := range m.clients {
+			style := itemStyle
+			if i == m.clientSelected {
+				style = selectedStyle
+			}
+			line := "  " + columns(
+				c.TTY, c.User, fmt.Sprintf("%dx%d", c.Width, c.Height), c.Session, formatIdle(c.Idle()))
+			if c.ReadOnly {
+				line += " " + i18n.T("tui.client_readonly")
+			}
+			b.WriteString(style.Render(line))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if m.status != "" {
+		b.WriteString(accentStyle.Render(" " + m.status))
+		b.WriteString("\n")
+	}
+	b.WriteString(hintStyle.Render(i18n.T("tui.clients_hints")))
+	return b.String()
+}
+
+// formatIdle 以紧凑形式显示空闲时长，例如 45s、3m、2h、4d
+func formatIdle(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// clientColumns 是客户端视图各列的显示宽度
+var clientColumns = []int{14, 10, 9, 16, 6}
+
+// columns 按显示宽度对齐各列，中文表头占两个字符宽度
+func columns(values ...string) string {
+	var b strings.Builder
+	for i, v := range values {
+		b.WriteString(v)
+		if i < len(values)-1 {
+			b.WriteString(strings.Repeat(" ", max(clientColumns[i]-lipgloss.Width(v), 0)+1))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/charmbracelet/bubbletea"
+)
+
+// 导出方式
+const (
+	exportFile      = "file"
+	exportEscapes   = "escapes"
+	exportClipboard = "clipboard"
+)
+
+type exportDoneMsg struct {
+	status string
+	err    error
+}
+
+// promptExport 选择导出方式，然后导出目标（窗格、窗口或会话）的滚动历史
+func (m Model) promptExport(target string) Model {
+	m = m.startPicker(i18n.T("tui.export_title", target),
+		[]string{exportFile, exportEscapes, exportClipboard}, "",
+		func(mode string) tea.Cmd {
+			return m.export(target, mode)
+		})
+	if m.picker != nil {
+		m.picker.labels = []string{
+			i18n.T("tui.export_file", m.exportDir),
+			i18n.T("tui.export_escapes"),
+			i18n.T("tui.export_clipboard"),
+		}
+	}
+	return m
+}
+
+func (m Model) export(target, mode string) tea.Cmd {
+	return func() tea.Msg {
+		if mode != exportClipboard {
+			path, err := m.manager.ExportScrollback(target, m.exportDir, mode == exportEscapes)
+			return exportDoneMsg{status: i18n.T("tui.export_saved", path), err: err}
+		}
+
+		content, err := m.manager.CaptureScrollback(target, false)
+		if err == nil {
+			err = m.manager.CopyToClipboard(content, m.output)
+		}
+		return exportDoneMsg{status: i18n.N("tui.export_copied", strings.Count(content, "\n")), err: err}
+	}
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/git"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/charmbracelet/bubbletea"
+)
+
+// gitCacheTTL 是会话目录 git 状态的缓存时间，会话列表每次刷新只重新读取过期的目录
+const gitCacheTTL = 30 * time.Second
+
+type gitLoadedMsg map[string]git.Status
+
+// loadGit 在后台读取每个会话工作目录的 git 状态，上一次读取未完成时不重复读取
+func (m Model) loadGit() (Model, tea.Cmd) {
+	if m.gitLoading || m.gitCache == nil {
+		return m, nil
+	}
+	m.gitLoading = true
+	var dirs []string
+	for _, s := range m.sessions {
+		dirs = append(dirs, s.Path)
+	}
+	cache := m.gitCache
+	return m, func() tea.Msg {
+		return gitLoadedMsg(cache.Statuses(dirs))
+	}
+}
+
+// toggleDirtyFilter 切换只显示有未提交修改的会话
+func (m Model) toggleDirtyFilter() (Model, tea.Cmd) {
+	m.dirtyOnly = !m.dirtyOnly
+	if !m.dirtyOnly {
+		m.status = ""
+		// 重新加载以恢复被过滤的会话
+		return m, m.loadSessions()
+	}
+	m.status = i18n.T("tui.filter_dirty")
+	return m.filterSessions(), nil
+}
+
+// filterSessions 只保留有未提交修改的会话，git 状态还没有读取到的会话也不显示
+func (m Model) filterSessions() Model {
+	if !m.dirtyOnly {
+		return m
+	}
+	sel := m.currentSelection()
+	var sessions []tmux.Session
+	for _, s := range m.sessions {
+		if status, ok := m.gitStatus[s.Path]; ok && status.Dirty() {
+			sessions = append(sessions, s)
+		}
+	}
+	m.sessions = sessions
+	return m.restoreSelection(sel)
+}
+
+// gitBadge 返回会话目录的 git 标记，例如 [main* ↑1]，不是 git 仓库时为空
+func (m Model) gitBadge(s tmux.Session, selected bool) string {
+	status, ok := m.gitStatus[s.Path]
+	if !ok {
+		return ""
+	}
+	badge := "[" + status.String() + "]"
+	if status.Dirty() && !selected {
+		badge = accentStyle.Render(badge)
+	}
+	return " " + badge
+}
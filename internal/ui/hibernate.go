@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/archive"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/charmbracelet/bubbletea"
+)
+
+type archivesLoadedMsg []archive.Session
+type archiveActionMsg struct {
+	err     error
+	status  string
+	session string // 恢复后要选中的会话
+}
+
+// loadArchives 读取存档目录中休眠和清理的会话
+func (m Model) loadArchives() tea.Cmd {
+	dir := m.archiveDir
+	if dir == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		sessions, _ := archive.List(dir)
+		return archivesLoadedMsg(sessions)
+	}
+}
+
+// rowCount 返回会话列表的行数：会话和休眠的会话
+func (m Model) rowCount() int {
+	return len(m.sessions) + len(m.archives)
+}
+
+// selectedArchive 返回选中的休眠会话，选中的不是休眠会话时 ok 为 false
+func (m Model) selectedArchive() (archive.Session, bool) {
+	i := m.selected - len(m.sessions)
+	if i < 0 || i >= len(m.archives) {
+		return archive.Session{}, false
+	}
+	return m.archives[i], true
+}
+
+// hibernate 保存选中的会话后关闭，运行 tmx 的当前会话不能休眠
+func (m Model) hibernate(s tmux.Session) (Model, tea.Cmd) {
+	if s.Name == m.manager.CurrentSession() {
+		m.status = i18n.T("tui.hibernate_current")
+		return m, nil
+	}
+	dir := m.archiveDir
+	m.status = i18n.T("tui.hibernating", s.Name)
+	return m, func() tea.Msg {
+		_, err := archive.SaveAndKill(m.manager, s, dir, archive.ReasonHibernate)
+		return archiveActionMsg{err: err, status: i18n.T("tui.hibernated", s.Name)}
+	}
+}
+
+// handleArchiveKey 处理选中休眠会话时的按键：Enter 恢复，x 确认后删除存档
+// ok 为 false 表示不是休眠会话的按键
+func (m Model) handleArchiveKey(key string) (Model, tea.Cmd, bool) {
+	s, ok := m.selectedArchive()
+	if !ok {
+		return m, nil, false
+	}
+	switch key {
+	case "enter":
+		m.status = i18n.T("tui.reviving", s.Name)
+		return m, func() tea.Msg {
+			if err := archive.Revive(m.manager, s); err != nil {
+				return archiveActionMsg{err: err}
+			}
+			return archiveActionMsg{status: i18n.T("tui.revived", s.Name), session: s.Name}
+		}, true
+
+	case "x":
+		return m.confirmRemoveArchive(s), nil, true
+
+	case "r", "D", "g", "w", "S", "E", "p", "d", "P", "H":
+		// 针对会话的操作对休眠会话无效
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// confirmRemoveArchive 删除存档前确认，存档中的滚动历史删除后无法找回，默认选中保留
+func (m Model) confirmRemoveArchive(s archive.Session) Model {
+	m = m.startPicker(i18n.T("tui.archive_remove_title", s.Name),
+		[]string{"keep", "remove"}, "keep",
+		func(choice string) tea.Cmd {
+			if choice != "remove" {
+				return nil
+			}
+			return func() tea.Msg {
+				return archiveActionMsg{err: archive.Remove(s), status: i18n.T("tui.archive_removed", s.Name)}
+			}
+		})
+	if m.picker != nil {
+		m.picker.labels = []string{
+			i18n.T("tui.archive_keep"),
+			i18n.T("tui.archive_remove"),
+		}
+	}
+	return m
+}
+
+// renderArchives 渲染会话列表下方的休眠会话
+func (m Model) renderArchives(b *strings.Builder) {
+	if len(m.archives) == 0 {
+		return
+	}
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render(i18n.T("tui.hibernated_title")))
+	b.WriteString("\n")
+	for j, s := range m.archives {
+		i := len(m.sessions) + j
+		style := itemStyle
+		if i == m.selected {
+			style = selectedStyle
+		}
+		line := fmt.Sprintf("  %s%s (%s) %s",
+			s.Name,
+			strings.Repeat(" ", max(40-len(s.Name), 1)),
+			formatTime(s.Saved),
+			i18n.N("ls.windows", len(s.Windows)),
+		)
+		if s.Reason == archive.ReasonReap {
+			line += " " + i18n.T("tui.archive_reaped")
+		}
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"os"
+	"sync"
+)
+
+// Output 是界面写入终端的输出，界面运行期间 OSC 52 等控制序列也要经过它写入，
+// 加锁保证控制序列不会插进一帧画面的中间
+// 嵌入 *os.File 是为了让 bubbletea 仍能识别终端并读取窗口大小
+type Output struct {
+	*os.File
+	mu sync.Mutex
+}
+
+// NewOutput 包装终端的输出，通常为 os.Stdout
+func NewOutput(f *os.File) *Output {
+	return &Output{File: f}
+}
+
+func (o *Output) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.File.Write(p)
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/proc"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/DreamCats/tmuxmanager/internal/watch"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type processesLoadedMsg struct {
+	target string
+	trees  []proc.PaneTree
+	err    error
+}
+
+// procRow 是进程视图中的一行：node 为 -1 时是窗格标题，否则是窗格中的进程
+type procRow struct {
+	tree int
+	node int
+}
+
+// showProcesses 打开 target（会话、窗口或窗格）的进程视图，返回时回到当前视图
+func (m Model) showProcesses(target string) (Model, tea.Cmd) {
+	if m.view != viewProcesses {
+		m.procReturn = m.view
+	}
+	m.view = viewProcesses
+	m.procTarget = target
+	m.procTrees = nil
+	m.procSelected = 0
+	m.status = ""
+	return m, m.loadProcesses()
+}
+
+// loadProcesses 读取目标中每个窗格的进程树和监听的端口
+func (m Model) loadProcesses() tea.Cmd {
+	target := m.procTarget
+	return func() tea.Msg {
+		panes, err := m.manager.ResolvePanes([]string{target})
+		if err != nil {
+			return processesLoadedMsg{target: target, err: err}
+		}
+		trees, err := proc.Trees(panes)
+		return processesLoadedMsg{target: target, trees: trees, err: err}
+	}
+}
+
+// procRows 返回进程视图的所有行
+func (m Model) procRows() []procRow {
+	var rows []procRow
+	for i, t := range m.procTrees {
+		rows = append(rows, procRow{tree: i, node: -1})
+		for j := range t.Nodes {
+			rows = append(rows, procRow{tree: i, node: j})
+		}
+	}
+	return rows
+}
+
+// handleProcesses 处理进程视图的按键
+func (m Model) handleProcesses(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := m.procRows()
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.quitting = true
+		return m, tea.Quit
+
+	case "esc", "p", "backspace":
+		m.view = m.procReturn
+		m.status = ""
+		if m.view == viewWindows {
+			return m, m.loadTree(m.treeSession)
+		}
+		return m, m.loadSessions()
+
+	case "up", "k":
+		if m.procSelected > 0 {
+			m.procSelected--
+		}
+
+	case "down", "j":
+		if m.procSelected < len(rows)-1 {
+			m.procSelected++
+		}
+
+	case "enter":
+		if m.procSelected < len(rows) {
+			pane := m.procTrees[rows[m.procSelected].tree].Pane
+			return m, attachTo(pane.Target(), tmux.AttachOptions{})
+		}
+	}
+	return m, nil
+}
+
+// renderProcesses 渲染进程视图：每个窗格的进程树、运行时长和监听的端口
+func (m Model) renderProcesses() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(i18n.T("tui.processes_title", m.procTarget)))
+	b.WriteString("\n\n")
+
+	width := m.width - 2
+	if width <= 0 {
+		width = 80
+	}
+	line := lipgloss.NewStyle().MaxWidth(width)
+
+	rows := m.procRows()
+	if len(rows) == 0 {
+		b.WriteString(itemStyle.Render(i18n.T("tui.loading_processes")))
+		b.WriteString("\n")
+	}
+
+	// 只显示选中行附近的一屏
+	visible := m.height - 8
+	if m.height == 0 || visible < 5 {
+		visible = 20
+	}
+	start := min(max(m.procSelected-visible/2, 0), max(len(rows)-visible, 0))
+	end := min(start+visible, len(rows))
+	for i := start; i < end; i++ {
+		row := rows[i]
+		selected := i == m.procSelected
+		tree := m.procTrees[row.tree]
+
+		var text string
+		if row.node < 0 {
+			p := tree.Pane
+			text = fmt.Sprintf("  %s  %s  %s", p.Target(), p.Command, p.Path)
+			if !selected {
+				text = accentStyle.Render(text)
+			}
+		} else {
+			n := tree.Nodes[row.node]
+			indent := ""
+			if n.Depth > 0 {
+				indent = strings.Repeat("  ", n.Depth-1) + "└ "
+			}
+			var ports string
+			for _, p := range n.Ports {
+				ports += " [" + p.String() + "]"
+			}
+			text = fmt.Sprintf("  %8d %7s  %s", n.PID, watch.FormatDuration(time.Since(n.Started)), indent)
+			// 命令行过长时截断，保证端口可见
+			cmdline := lipgloss.NewStyle().MaxWidth(max(width-lipgloss.Width(text)-lipgloss.Width(ports), 10)).Render(n.Cmdline)
+			if !selected {
+				ports = accentStyle.Render(ports)
+			}
+			text += cmdline + ports
+		}
+		style := itemStyle
+		if selected {
+			style = selectedStyle
+		}
+		b.WriteString(style.Render(line.Render(text)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.status != "" {
+		b.WriteString(accentStyle.Render(" " + m.status))
+		b.WriteString("\n")
+	}
+	b.WriteString(hintStyle.Render(i18n.T("tui.processes_hints")))
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/charmbracelet/bubbletea"
+)
+
+// picker 从列表中选择一项，例如选择目标会话
+type picker struct {
+	title    string
+	items    []string
+	labels   []string // 显示的文字，为空时显示 items
+	selected int
+	onPick   func(string) tea.Cmd
+}
+
+// startInput 进入输入模式，确认后以输入内容调用 submit
+func (m Model) startInput(title, prompt, value string, submit func(string) tea.Cmd) Model {
+	m.inputMode = true
+	m.inputTitle = title
+	m.inputPrompt = prompt
+	m.inputBuffer = value
+	m.inputSubmit = submit
+	m.inputOptional = false
+	m.inputTab = nil
+	return m
+}
+
+// startPicker 打开选择器，current 为默认选中项
+func (m Model) startPicker(title string, items []string, current string, onPick func(string) tea.Cmd) Model {
+	if len(items) == 0 {
+		return m
+	}
+	p := &picker{title: title, items: items, onPick: onPick}
+	for i, item := range items {
+		if item == current {
+			p.selected = i
+		}
+	}
+	m.picker = p
+	return m
+}
+
+// handlePicker 处理选择器的按键
+func (m Model) handlePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := *m.picker
+	switch msg.String() {
+	case "esc", "q":
+		m.picker = nil
+		return m, nil
+
+	case "up", "k":
+		if p.selected > 0 {
+			p.selected--
+		}
+
+	case "down", "j":
+		if p.selected < len(p.items)-1 {
+			p.selected++
+		}
+
+	case "enter":
+		m.picker = nil
+		return m, p.onPick(p.items[p.selected])
+	}
+	m.picker = &p
+	return m, nil
+}
+
+// renderPicker 渲染选择器
+func (m Model) renderPicker() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(m.picker.title))
+	b.WriteString("\n\n")
+
+	for i, item := range m.picker.items {
+		style := itemStyle
+		if i == m.picker.selected {
+			style = selectedStyle
+		}
+		if i < len(m.picker.labels) {
+			item = m.picker.labels[i]
+		}
+		b.WriteString(style.Render("  " + item))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render(i18n.T("tui.picker_hints")))
+	return b.String()
+}
+
+// sessionNames 返回会话名称，排除 exclude
+func (m Model) sessionNames(exclude string) []string {
+	var names []string
+	for _, s := range m.sessions {
+		if s.Name != exclude {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/record"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/charmbracelet/bubbletea"
+)
+
+// toggleRecording 开始或停止录制选中的窗格，选中窗口行时作用于该窗口的当前窗格
+func (m Model) toggleRecording() tea.Cmd {
+	target := m.tree[m.treeSelected].target()
+	return func() tea.Msg {
+		pane, err := m.activePane(target)
+		if err != nil || pane == nil {
+			return treeActionMsg{err: err, focus: target}
+		}
+
+		if pane.Recording != "" {
+			err := record.Stop(m.manager, *pane)
+			return treeActionMsg{err: err, focus: target, status: i18n.T("tui.record_stopped", pane.Recording)}
+		}
+		path, err := record.Start(m.manager, *pane, m.recordOpts)
+		return treeActionMsg{err: err, focus: target, status: i18n.T("tui.record_started", path)}
+	}
+}
+
+// activePane 返回目标中的当前窗格，选中窗口行时为该窗口的当前窗格
+func (m Model) activePane(target string) (*tmux.Pane, error) {
+	panes, err := m.manager.ResolvePanes([]string{target})
+	if err != nil {
+		return nil, err
+	}
+	var pane *tmux.Pane
+	for i := range panes {
+		if pane == nil || panes[i].Active {
+			pane = &panes[i]
+		}
+	}
+	return pane, nil
+}
@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/record"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	replayTick = 100 * time.Millisecond
+	replaySeek = 5 * time.Second
+)
+
+// replaySpeeds 是可选的回放速度
+var replaySpeeds = []float64{0.5, 1, 2, 4, 8, 16}
+
+type replayTickMsg time.Time
+
+// Replay 是 tmx replay 的回放界面，按录制时的节奏逐行显示输出
+type Replay struct {
+	title   string
+	entries []record.Entry
+	pos     time.Duration // 当前回放位置
+	playing bool
+	speed   int // replaySpeeds 的下标
+	width   int
+	height  int
+}
+
+// NewReplay 创建回放界面，entries 由 record.Load 读取
+func NewReplay(title string, entries []record.Entry) Replay {
+	return Replay{title: title, entries: entries, playing: true, speed: 1}
+}
+
+// Init 开始回放
+func (r Replay) Init() tea.Cmd {
+	return replayTicker()
+}
+
+func replayTicker() tea.Cmd {
+	return tea.Tick(replayTick, func(t time.Time) tea.Msg { return replayTickMsg(t) })
+}
+
+// end 返回录制的总时长
+func (r Replay) end() time.Duration {
+	if len(r.entries) == 0 {
+		return 0
+	}
+	return r.entries[len(r.entries)-1].Offset
+}
+
+// Update 处理按键和计时
+func (r Replay) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		r.width = msg.Width
+		r.height = msg.Height
+
+	case replayTickMsg:
+		if r.playing {
+			r.pos += time.Duration(float64(replayTick) * replaySpeeds[r.speed])
+			if r.pos >= r.end() {
+				r.pos = r.end()
+				r.playing = false
+			}
+		}
+		return r, replayTicker()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return r, tea.Quit
+
+		case " ", "p":
+			// 播放结束后再次播放从头开始
+			if !r.playing && r.pos >= r.end() {
+				r.pos = 0
+			}
+			r.playing = !r.playing
+
+		case "left", "h":
+			r.pos = max(r.pos-replaySeek, 0)
+
+		case "right", "l":
+			r.pos = min(r.pos+replaySeek, r.end())
+
+		case "-":
+			r.speed = max(r.speed-1, 0)
+
+		case "+", "=":
+			r.speed = min(r.speed+1, len(replaySpeeds)-1)
+
+		case "g", "home":
+			r.pos = 0
+
+		case "G", "end":
+			r.pos = r.end()
+			r.playing = false
+		}
+	}
+	return r, nil
+}
+
+// View 显示到当前位置为止的最后一屏输出和进度
+func (r Replay) View() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(i18n.T("replay.title", r.title)))
+	b.WriteString("\n\n")
+
+	rows := r.height - 6
+	if rows <= 0 {
+		rows = 20
+	}
+	width := r.width - 2
+	if width <= 0 {
+		width = 80
+	}
+	line := lipgloss.NewStyle().MaxWidth(width)
+
+	// 当前位置之前（含）的行数
+	shown := sort.Search(len(r.entries), func(i int) bool { return r.entries[i].Offset > r.pos })
+	start := max(shown-rows, 0)
+	for _, e := range r.entries[start:shown] {
+		b.WriteString(itemStyle.Render(line.Render(e.Text)))
+		b.WriteString("\n")
+	}
+	for i := shown - start; i < rows; i++ {
+		b.WriteString("\n")
+	}
+
+	state := "⏸"
+	if r.playing {
+		state = "▶"
+	}
+	status := fmt.Sprintf("%s %s / %s  %gx", state, formatOffset(r.pos), formatOffset(r.end()), replaySpeeds[r.speed])
+	if shown > 0 && !r.entries[shown-1].At.IsZero() {
+		status += "  " + r.entries[shown-1].At.Format("2006-01-02 15:04:05")
+	}
+	b.WriteString("\n")
+	b.WriteString(accentStyle.Render(" " + status))
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render(i18n.T("replay.hints")))
+	return b.String()
+}
+
+// formatOffset 以 分:秒 显示回放位置
+func formatOffset(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// searchContext 是匹配行前后显示的行数
+const searchContext = 2
+
+type searchStartMsg struct{ query string }
+type searchResultsMsg struct {
+	query   string
+	matches []tmux.Match
+	err     error
+}
+type matchShownMsg struct {
+	target string
+	err    error
+}
+
+// promptSearch 询问要在滚动历史中搜索的正则表达式
+func (m Model) promptSearch() Model {
+	return m.startInput(i18n.T("tui.search_title"), i18n.T("tui.search_prompt"), m.searchQuery,
+		func(query string) tea.Cmd {
+			return func() tea.Msg { return searchStartMsg{query} }
+		})
+}
+
+// runSearch 在所有窗格中搜索，跳过运行 TUI 的窗格以免匹配到输入的正则本身
+func (m Model) runSearch(query string) tea.Cmd {
+	return func() tea.Msg {
+		re, err := regexp.Compile(query)
+		if err != nil {
+			return searchResultsMsg{query: query, err: err}
+		}
+		matches, err := m.manager.SearchPanes(re, tmux.SearchOptions{
+			Context:  searchContext,
+			MaxLines: tmux.DefaultSearchLines,
+			Exclude:  os.Getenv("TMUX_PANE"),
+		})
+		return searchResultsMsg{query: query, matches: matches, err: err}
+	}
+}
+
+// handleSearch 处理搜索视图的按键
+func (m Model) handleSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.quitting = true
+		return m, tea.Quit
+
+	case "esc", "backspace":
+		m.view = viewSessions
+		m.status = ""
+		return m, m.loadSessions()
+
+	case "up", "k":
+		if m.matchSelected > 0 {
+			m.matchSelected--
+		}
+
+	case "down", "j":
+		if m.matchSelected < len(m.matches)-1 {
+			m.matchSelected++
+		}
+
+	case "/":
+		return m.promptSearch(), nil
+
+	case "enter":
+		if m.matchSelected < len(m.matches) {
+			return m, attachTo(m.matches[m.matchSelected].Pane.Target(), tmux.AttachOptions{})
+		}
+
+	case "v":
+		// 先让窗格进入复制模式并定位到匹配，再连接过去
+		if m.matchSelected < len(m.matches) {
+			match := m.matches[m.matchSelected]
+			return m, func() tea.Msg {
+				return matchShownMsg{target: match.Pane.Target(), err: m.manager.ShowMatch(match)}
+			}
+		}
+	}
+	return m, nil
+}
+
+// renderSearch 渲染搜索结果：匹配列表和选中匹配的上下文
+func (m Model) renderSearch() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(i18n.T("tui.search_results_title", m.searchQuery)))
+	b.WriteString("\n\n")
+
+	width := m.width - 2
+	if width <= 0 {
+		width = 80
+	}
+	line := lipgloss.NewStyle().MaxWidth(width)
+
+	switch {
+	case m.searching:
+		b.WriteString(itemStyle.Render(i18n.T("tui.searching")))
+		b.WriteString("\n")
+	case len(m.matches) == 0:
+		b.WriteString(itemStyle.Render(i18n.T("tui.no_matches")))
+		b.WriteString("\n")
+	default:
+		b.WriteString(hintStyle.Render(i18n.N("tui.match_count", len(m.matches))))
+		b.WriteString("\n")
+
+		// 只显示选中项附近的一屏匹配
+		rows := m.height - 12 - 2*searchContext
+		if m.height == 0 || rows < 5 {
+			rows = 10
+		}
+		start := min(max(m.matchSelected-rows/2, 0), max(len(m.matches)-rows, 0))
+		end := min(start+rows, len(m.matches))
+		for i := start; i < end; i++ {
+			match := m.matches[i]
+			style := itemStyle
+			if i == m.matchSelected {
+				style = selectedStyle
+			}
+			text := fmt.Sprintf("  %-14s %6d  %s", match.Pane.Target(), match.Line, strings.TrimSpace(match.Text))
+			b.WriteString(style.Render(line.Render(text)))
+			b.WriteString("\n")
+		}
+
+		// 选中匹配的上下文
+		match := m.matches[m.matchSelected]
+		b.WriteString("\n")
+		for _, l := range match.Before {
+			b.WriteString(hintStyle.Render(line.Render("  " + l)))
+			b.WriteString("\n")
+		}
+		b.WriteString(accentStyle.Render(line.Render(" ▸" + match.Text)))
+		b.WriteString("\n")
+		for _, l := range match.After {
+			b.WriteString(hintStyle.Render(line.Render("  " + l)))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if m.status != "" {
+		b.WriteString(accentStyle.Render(" " + m.status))
+		b.WriteString("\n")
+	}
+	b.WriteString(hintStyle.Render(i18n.T("tui.search_hints")))
+	return b.String()
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/charmbracelet/bubbletea"
+)
+
+type sendPreviewMsg struct {
+	panes   []tmux.Pane
+	pattern string
+	command string
+	err     error
+}
+type sendDoneMsg struct {
+	sent int
+	err  error
+}
+
+// promptSendTargets 询问发送目标，默认为选中的会话，可改为通配符（例如 web-*）
+func (m Model) promptSendTargets(current string) Model {
+	return m.startInput(i18n.T("tui.send_title"), i18n.T("tui.send_targets_prompt"), current,
+		func(pattern string) tea.Cmd {
+			return func() tea.Msg { return promptSendCommandMsg{pattern} }
+		})
+}
+
+type promptSendCommandMsg struct{ pattern string }
+
+// promptSendCommand 询问要发送的命令，确认前先预览目标窗格
+// 按 Tab 切换为发送按键（例如 C-c），不追加回车
+func (m Model) promptSendCommand(pattern string) Model {
+	m = m.startInput("", "", "", func(command string) tea.Cmd {
+		return m.previewSend(pattern, command)
+	})
+	m.inputTab = func(m Model) Model {
+		return m.setSendKeys(pattern, !m.sendKeys)
+	}
+	return m.setSendKeys(pattern, false)
+}
+
+// setSendKeys 切换发送命令和发送按键，同时更新输入框的标题和提示
+func (m Model) setSendKeys(pattern string, keys bool) Model {
+	m.sendKeys = keys
+	if keys {
+		m.inputTitle = i18n.T("tui.send_keys_title")
+		m.inputPrompt = i18n.T("tui.send_keys_prompt", pattern)
+	} else {
+		m.inputTitle = i18n.T("tui.send_title")
+		m.inputPrompt = i18n.T("tui.send_command_prompt", pattern)
+	}
+	return m
+}
+
+func (m Model) previewSend(pattern, command string) tea.Cmd {
+	return func() tea.Msg {
+		panes, err := m.manager.ResolvePanes(strings.Fields(pattern))
+		return sendPreviewMsg{panes: panes, pattern: pattern, command: command, err: err}
+	}
+}
+
+// handleSendPreview 处理发送预览的按键：Enter 发送，Esc 取消
+func (m Model) handleSendPreview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.sendPanes = nil
+
+	case "enter":
+		panes, command, keys := m.sendPanes, m.sendCommand, m.sendKeys
+		m.sendPanes = nil
+		return m, func() tea.Msg {
+			sent := 0
+			for _, p := range panes {
+				var err error
+				if keys {
+					err = m.manager.SendKeys(p.Target(), strings.Fields(command)...)
+				} else {
+					err = m.manager.SendCommand(p.Target(), command)
+				}
+				if err != nil {
+					return sendDoneMsg{sent: sent, err: err}
+				}
+				sent++
+			}
+			return sendDoneMsg{sent: sent}
+		}
+	}
+	return m, nil
+}
+
+// renderSendPreview 渲染发送前的目标预览
+func (m Model) renderSendPreview() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(i18n.N("tui.send_preview_title", len(m.sendPanes))))
+	b.WriteString("\n\n")
+	if m.sendKeys {
+		b.WriteString(itemStyle.Render(i18n.T("tui.send_keys_preview", m.sendCommand)))
+	} else {
+		b.WriteString(itemStyle.Render("$ " + m.sendCommand))
+	}
+	b.WriteString("\n\n")
+
+	for _, p := range m.sendPanes {
+		b.WriteString(itemStyle.Render(fmt.Sprintf("  %-20s %s", p.Target(), p.Command)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render(i18n.T("tui.send_hints")))
+	return b.String()
+}
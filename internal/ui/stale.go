@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/archive"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/reap"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/DreamCats/tmuxmanager/internal/watch"
+	"github.com/charmbracelet/bubbletea"
+)
+
+type staleLoadedMsg struct {
+	candidates []reap.Candidate
+	err        error
+}
+type staleActionMsg struct {
+	err    error
+	status string
+}
+type sessionPinnedMsg struct{ err error }
+
+// showStale 打开空闲会话视图，列出按清理策略可以清理的会话
+func (m Model) showStale() (Model, tea.Cmd) {
+	m.view = viewStale
+	m.stale = nil
+	m.staleSelected = 0
+	m.staleLoaded = false
+	m.status = ""
+	return m, m.loadStale()
+}
+
+// loadStale 读取会话并按清理策略筛选
+func (m Model) loadStale() tea.Cmd {
+	policy := m.reapPolicy
+	return func() tea.Msg {
+		sessions, err := m.manager.ListSessions()
+		if err != nil {
+			return staleLoadedMsg{err: err}
+		}
+		return staleLoadedMsg{candidates: policy.Candidates(sessions)}
+	}
+}
+
+// togglePinned 标记或取消标记会话为保留
+func (m Model) togglePinned(s tmux.Session) tea.Cmd {
+	return func() tea.Msg {
+		return sessionPinnedMsg{err: m.manager.SetPinned(s.Name, !s.Pinned)}
+	}
+}
+
+// pinnedBadge 返回会话的保留标记
+func (m Model) pinnedBadge(s tmux.Session, selected bool) string {
+	if !m.reapPolicy.IsPinned(s) {
+		return ""
+	}
+	badge := i18n.T("tui.pinned")
+	if !selected {
+		badge = accentStyle.Render(badge)
+	}
+	return " " + badge
+}
+
+// handleStale 处理空闲会话视图的按键
+func (m Model) handleStale(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.quitting = true
+		return m, tea.Quit
+
+	case "esc", "i", "backspace":
+		m.view = viewSessions
+		m.status = ""
+		return m, m.loadSessions()
+
+	case "up", "k":
+		if m.staleSelected > 0 {
+			m.staleSelected--
+		}
+
+	case "down", "j":
+		if m.staleSelected < len(m.stale)-1 {
+			m.staleSelected++
+		}
+
+	case "enter":
+		if m.staleSelected < len(m.stale) {
+			return m, attachTo(m.stale[m.staleSelected].Session.Name, tmux.AttachOptions{})
+		}
+
+	case "x":
+		if m.staleSelected < len(m.stale) {
+			s := m.stale[m.staleSelected].Session
+			dir := m.archiveDir
+			m.status = i18n.T("tui.reaping", s.Name)
+			return m, func() tea.Msg {
+				path, err := archive.SaveAndKill(m.manager, s, dir, archive.ReasonReap)
+				return staleActionMsg{err: err, status: i18n.T("tui.reaped", s.Name, path)}
+			}
+		}
+
+	case "P":
+		if m.staleSelected < len(m.stale) {
+			s := m.stale[m.staleSelected].Session
+			return m, func() tea.Msg {
+				return staleActionMsg{err: m.manager.SetPinned(s.Name, true), status: i18n.T("tui.pinned_session", s.Name)}
+			}
+		}
+	}
+	return m, nil
+}
+
+// renderStale 渲染空闲会话视图
+func (m Model) renderStale() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(i18n.T("tui.stale_title", watch.FormatDuration(m.reapPolicy.Idle))))
+	b.WriteString("\n\n")
+
+	switch {
+	case !m.staleLoaded:
+		b.WriteString(itemStyle.Render(i18n.T("tui.loading_stale")))
+		b.WriteString("\n")
+	case len(m.stale) == 0:
+		b.WriteString(itemStyle.Render(i18n.T("tui.no_stale")))
+		b.WriteString("\n")
+	}
+
+	for i, c := range m.stale {
+		style := itemStyle
+		if i == m.staleSelected {
+			style = selectedStyle
+		}
+		s := c.Session
+		line := fmt.Sprintf("  %s%s%s  %s  %s",
+			s.Name,
+			strings.Repeat(" ", max(30-len(s.Name), 1)),
+			i18n.T("reap.idle", watch.FormatDuration(c.Idle)),
+			i18n.N("ls.windows", s.Windows),
+			s.Path,
+		)
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.status != "" {
+		b.WriteString(accentStyle.Render(" " + m.status))
+		b.WriteString("\n")
+	}
+	b.WriteString(hintStyle.Render(i18n.T("tui.stale_hints")))
+	return b.String()
+}
@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/config"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme 定义一套配色，颜色为空表示使用终端默认颜色
+type Theme struct {
+	Name       string
+	Title      string
+	SelectedFg string
+	SelectedBg string
+	Hint       string
+	Accent     string
+	// Reverse 为 true 时选中行使用反色而不是背景色（单色主题）
+	Reverse bool
+}
+
+// 内置主题
+var builtinThemes = map[string]Theme{
+	"dark": {
+		Name:       "dark",
+		Title:      "#86AAEC",
+		SelectedFg: "#EEEDFF",
+		SelectedBg: "#7D56F4",
+		Hint:       "#626262",
+		Accent:     "#04B575",
+	},
+	"light": {
+		Name:       "light",
+		Title:      "#1F4FA3",
+		SelectedFg: "#FFFFFF",
+		SelectedBg: "#5A3FD0",
+		Hint:       "#6B6B6B",
+		Accent:     "#0A7D48",
+	},
+	"high-contrast": {
+		Name:       "high-contrast",
+		Title:      "#FFFF00",
+		SelectedFg: "#000000",
+		SelectedBg: "#FFFF00",
+		Hint:       "#FFFFFF",
+		Accent:     "#00FFFF",
+	},
+	"monochrome": {
+		Name:    "monochrome",
+		Reverse: true,
+	},
+}
+
+// Styles 定义 UI 样式，由 ApplyTheme 根据主题生成
+var (
+	titleStyle    lipgloss.Style
+	itemStyle     lipgloss.Style
+	selectedStyle lipgloss.Style
+	hintStyle     lipgloss.Style
+	accentStyle   lipgloss.Style
+
+	activeIndicator = "▶ "
+)
+
+func init() {
+	ApplyTheme(builtinThemes["dark"])
+}
+
+// ApplyTheme 使用指定主题重新生成全部样式
+func ApplyTheme(t Theme) {
+	titleStyle = foreground(lipgloss.NewStyle().Bold(true).Padding(0, 1), t.Title)
+
+	itemStyle = lipgloss.NewStyle().
+		Padding(0, 1)
+
+	selectedStyle = lipgloss.NewStyle().
+		Padding(0, 1).
+		Bold(true)
+	if t.Reverse {
+		selectedStyle = selectedStyle.Reverse(true)
+	} else {
+		selectedStyle = foreground(selectedStyle, t.SelectedFg)
+		if t.SelectedBg != "" {
+			selectedStyle = selectedStyle.Background(lipgloss.Color(t.SelectedBg))
+		}
+	}
+
+	hintStyle = foreground(lipgloss.NewStyle().Padding(0, 1), t.Hint)
+	if t.Hint == "" {
+		hintStyle = hintStyle.Faint(true)
+	}
+
+	accentStyle = foreground(lipgloss.NewStyle(), t.Accent)
+}
+
+// foreground 仅在颜色非空时设置前景色
+func foreground(s lipgloss.Style, color string) lipgloss.Style {
+	if color == "" {
+		return s
+	}
+	return s.Foreground(lipgloss.Color(color))
+}
+
+// ResolveTheme 根据配置选择主题
+// 未指定主题（或 auto）时：设置了 NO_COLOR 使用 monochrome，否则按终端背景选择 dark/light
+// 显式配置的主题优先于 NO_COLOR
+func ResolveTheme(settings *config.Settings) (Theme, error) {
+	name := strings.TrimSpace(settings.Theme)
+	if name == "" || name == "auto" {
+		return autoTheme(), nil
+	}
+	return LookupTheme(name, settings.Themes)
+}
+
+// LookupTheme 按名称查找内置或自定义主题
+func LookupTheme(name string, custom map[string]config.ThemeConfig) (Theme, error) {
+	if spec, ok := custom[name]; ok {
+		base := builtinThemes["dark"]
+		if spec.Base != "" {
+			b, ok := builtinThemes[spec.Base]
+			if !ok {
+				return Theme{}, fmt.Errorf("theme %q: unknown base theme %q", name, spec.Base)
+			}
+			base = b
+		}
+		base.Name = name
+		override(&base.Title, spec.Title)
+		override(&base.SelectedFg, spec.SelectedFg)
+		override(&base.SelectedBg, spec.SelectedBg)
+		override(&base.Hint, spec.Hint)
+		override(&base.Accent, spec.Accent)
+		return base, nil
+	}
+	if t, ok := builtinThemes[name]; ok {
+		return t, nil
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q", name)
+}
+
+// ThemeNames 返回所有可用主题名称，内置主题在前
+func ThemeNames(custom map[string]config.ThemeConfig) []string {
+	names := []string{"dark", "light", "high-contrast", "monochrome"}
+	extra := make([]string, 0, len(custom))
+	for name := range custom {
+		if _, ok := builtinThemes[name]; !ok {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	return append(names, extra...)
+}
+
+// PreviewTheme 渲染主题示例，用于 tmx theme preview
+func PreviewTheme(t Theme) string {
+	ApplyTheme(t)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(t.Name))
+	b.WriteString("\n")
+	b.WriteString(selectedStyle.Render("  dev-server"))
+	b.WriteString("\n")
+	b.WriteString(itemStyle.Render(accentStyle.Render(activeIndicator) + "backend-api"))
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("[Enter] [d] [n] [x] [q]"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// autoTheme 根据环境自动选择主题，优先级：NO_COLOR > COLORFGBG > 查询终端背景色
+func autoTheme() Theme {
+	if os.Getenv("NO_COLOR") != "" {
+		return builtinThemes["monochrome"]
+	}
+	dark, ok := colorFGBGDark(os.Getenv("COLORFGBG"))
+	if !ok {
+		dark = hasDarkBackground()
+	}
+	if dark {
+		return builtinThemes["dark"]
+	}
+	return builtinThemes["light"]
+}
+
+// colorFGBGDark 解析 COLORFGBG（如 "15;0" 或 "0;default;15"），最后一段为背景色；
+// 0-6 和 8 视为深色，ok 为 false 表示无法判断
+func colorFGBGDark(value string) (dark, ok bool) {
+	fields := strings.Split(value, ";")
+	if len(fields) < 2 {
+		return false, false
+	}
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return false, false
+	}
+	return bg <= 6 || bg == 8, true
+}
+
+// hasDarkBackground 通过 OSC 11 查询终端背景色，查询失败时视为深色
+func hasDarkBackground() bool {
+	term := os.Getenv("TERM")
+	if !strings.HasPrefix(term, "screen") && !strings.HasPrefix(term, "tmux") {
+		return lipgloss.HasDarkBackground()
+	}
+	// termenv 在 screen/tmux 下不发查询，这里伪装 TERM 绕过；
+	// tmux 3.3 起会用客户端终端的背景色应答 OSC 11
+	return termenv.NewOutput(os.Stdout, termenv.WithEnvironment(clientEnviron{})).HasDarkBackground()
+}
+
+// clientEnviron 把 TERM 报告为普通终端，其余环境变量原样返回
+type clientEnviron struct{}
+
+func (clientEnviron) Environ() []string { return os.Environ() }
+
+func (clientEnviron) Getenv(key string) string {
+	if key == "TERM" {
+		return "xterm-256color"
+	}
+	return os.Getenv(key)
+}
+
+func override(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
@@ -0,0 +1,51 @@
+package ui
+
+import "testing"
+
+func TestColorFGBGDark(t *testing.T) {
+	tests := []struct {
+		value    string
+		wantDark bool
+		wantOK   bool
+	}{
+		{"15;0", true, true},
+		{"0;15", false, true},
+		{"0;7", false, true},
+		{"7;8", true, true},
+		{"15;default;0", true, true},
+		{"0;default;11", false, true},
+		{"", false, false},
+		{"15", false, false},
+		{"15;default", false, false},
+		{"15;16", false, false},
+	}
+	for _, tt := range tests {
+		dark, ok := colorFGBGDark(tt.value)
+		if dark != tt.wantDark || ok != tt.wantOK {
+			t.Errorf("colorFGBGDark(%q) = %v, %v, want %v, %v", tt.value, dark, ok, tt.wantDark, tt.wantOK)
+		}
+	}
+}
+
+func TestAutoTheme(t *testing.T) {
+	tests := []struct {
+		name      string
+		noColor   string
+		colorFGBG string
+		want      string
+	}{
+		{"dark background", "", "15;0", "dark"},
+		{"light background", "", "0;15", "light"},
+		// NO_COLOR 优先于 COLORFGBG
+		{"no color", "1", "0;15", "monochrome"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("COLORFGBG", tt.colorFGBG)
+			if got := autoTheme().Name; got != tt.want {
+				t.Errorf("autoTheme() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,56 +2,106 @@ package ui
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
+	"github.com/DreamCats/tmuxmanager/internal/archive"
+	"github.com/DreamCats/tmuxmanager/internal/git"
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/proc"
+	"github.com/DreamCats/tmuxmanager/internal/reap"
+	"github.com/DreamCats/tmuxmanager/internal/record"
 	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/charmbracelet/bubbletea"
 )
 
-// Styles 定义 UI 样式
-var (
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#86AAEC")).
-			Padding(0, 1)
-
-	itemStyle = lipgloss.NewStyle().
-			Padding(0, 1)
+// viewMode 是 TUI 当前显示的视图
+type viewMode int
 
-	selectedStyle = lipgloss.NewStyle().
-			Padding(0, 1).
-			Foreground(lipgloss.Color("#EEEDFF")).
-			Background(lipgloss.Color("#7D56F4")).
-			Bold(true)
-
-	activeIndicator = "▶ "
-
-	hintStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
-			Padding(0, 1)
+const (
+	viewSessions  viewMode = iota // 会话列表
+	viewWindows                   // 选中会话的窗口和窗格
+	viewClients                   // 连接到服务器的客户端
+	viewSearch                    // 滚动历史的搜索结果
+	viewProcesses                 // 窗格中的进程树和监听的端口
+	viewStale                     // 按清理策略可以清理的空闲会话
 )
 
+// attachKeys 是连接会话的按键及对应的连接方式
+var attachKeys = map[string]tmux.AttachOptions{
+	"enter": {},
+	"r":     {ReadOnly: true},
+	"D":     {DetachOthers: true},
+	"g":     {Grouped: true},
+}
+
 // Model 是 TUI 的状态模型
 type Model struct {
-	sessions         []tmux.Session
-	selected         int
-	manager          *tmux.Manager
-	quitting         bool
-	width            int
-	height           int
-	inputMode        bool
-	inputBuffer      string
-	newSessionName   string    // 新创建的会话名称
-	attachSessionName string    // 要附加的会话名称
+	sessions       []tmux.Session
+	selected       int
+	manager        *tmux.Manager
+	quitting       bool
+	width          int
+	height         int
+	inputMode      bool
+	inputBuffer    string
+	inputTitle     string
+	inputPrompt    string
+	inputSubmit    func(string) tea.Cmd // 输入确认后执行
+	inputOptional  bool                 // 允许提交空内容
+	inputTab       func(Model) Model    // 输入时按 Tab 执行，为空时忽略 Tab
+	picker         *picker              // 不为空时显示选择器
+	newSessionName string               // 新创建的会话名称
+	attachTarget   string               // 要连接的目标（会话、窗口或窗格）
+	attachOpts     tmux.AttachOptions   // 连接方式
+	popup          bool                 // 是否运行在 display-popup 中
+	exportDir      string               // 滚动历史导出目录
+	output         io.Writer            // 界面的输出，复制到剪贴板时写入 OSC 52
+	recordOpts     record.Options       // 窗格录制的目录和格式
+	recording      map[string]int       // 每个会话中正在录制的窗格数
+	finished       map[string]int       // 每个会话中等待的命令已经结束的窗格数
+	showUsage      bool                 // 显示 CPU 和内存列
+	sortKey        proc.SortKey         // 会话列表按资源占用排序
+	usage          proc.Resources
+	snapshot       *proc.Snapshot // 上一次统计的进程快照，用于计算 CPU 占用
+	procTarget     string         // 进程视图的目标
+	procTrees      []proc.PaneTree
+	procSelected   int
+	procReturn     viewMode // 离开进程视图后回到的视图
+	gitCache       *git.Cache
+	gitStatus      map[string]git.Status // 按会话工作目录
+	gitLoading     bool
+	dirtyOnly      bool // 只显示有未提交修改的会话
+	reapPolicy     reap.Policy
+	archiveDir     string            // 清理和休眠会话时保存存档的目录
+	archives       []archive.Session // 会话列表下方的休眠会话
+	stale          []reap.Candidate
+	staleSelected  int
+	staleLoaded    bool
+	view           viewMode
+	tree           []treeItem // 窗口视图的行
+	treeSelected   int
+	treeSession    string      // 窗口视图所属的会话
+	treeFocus      string      // 刷新后要选中的目标
+	status         string      // 最近一次操作的错误
+	sendPanes      []tmux.Pane // 不为空时显示发送预览
+	sendCommand    string
+	sendKeys       bool // 按 tmux 键名发送按键，而不是发送命令并回车
+	clients        []tmux.Client
+	clientSelected int
+	searchQuery    string
+	searching      bool
+	matches        []tmux.Match
+	matchSelected  int
 }
 
 // Messages
 type sessionsLoadedMsg []tmux.Session
 type sessionAttachedMsg struct {
-	err  error
-	name string
+	err    error
+	target string
+	opts   tmux.AttachOptions
 }
 type sessionDetachedMsg struct{ err error }
 type sessionCreatedMsg struct {
@@ -62,19 +112,40 @@ type sessionKilledMsg struct{ err error }
 
 // Init 初始化 TUI
 func (m Model) Init() tea.Cmd {
-	return m.loadSessions()
+	return tea.Batch(m.loadSessions(), refreshTicker())
 }
 
 // Update 处理事件
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// 输入模式下处理
+		// 选择器和输入模式优先处理
+		if m.picker != nil {
+			return m.handlePicker(msg)
+		}
+		if m.sendPanes != nil {
+			return m.handleSendPreview(msg)
+		}
 		if m.inputMode {
 			return m.handleInput(msg)
 		}
+		switch m.view {
+		case viewWindows:
+			return m.handleTree(msg)
+		case viewClients:
+			return m.handleClients(msg)
+		case viewSearch:
+			return m.handleSearch(msg)
+		case viewProcesses:
+			return m.handleProcesses(msg)
+		case viewStale:
+			return m.handleStale(msg)
+		}
 
 		// 正常模式
+		if model, cmd, ok := m.handleArchiveKey(msg.String()); ok {
+			return model, cmd
+		}
 		switch msg.String() {
 		case "ctrl+c", "q", "esc":
 			m.quitting = true
@@ -86,21 +157,70 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case "down", "j":
-			if m.selected < len(m.sessions)-1 {
+			if m.selected < m.rowCount()-1 {
 				m.selected++
 			}
 
-		case "enter":
-			return m, m.attachSession()
+		case "enter", "r", "D", "g":
+			return m, m.attachSession(attachKeys[msg.String()])
+
+		case "w":
+			if m.selected >= 0 && m.selected < len(m.sessions) {
+				return m.showTree(m.sessions[m.selected].Name)
+			}
+
+		case "S":
+			if m.selected >= 0 && m.selected < len(m.sessions) {
+				return m.promptSendTargets(m.sessions[m.selected].Name), nil
+			}
+
+		case "/":
+			return m.promptSearch(), nil
+
+		case "E":
+			if m.selected >= 0 && m.selected < len(m.sessions) {
+				return m.promptExport(m.sessions[m.selected].Name), nil
+			}
+
+		case "c":
+			m.view = viewClients
+			m.clientSelected = 0
+			m.status = ""
+			return m, m.loadClients()
 
 		case "n":
-			m.inputMode = true
-			m.inputBuffer = ""
-			return m, nil
+			return m.startInput(i18n.T("tui.new_title"), i18n.T("tui.new_prompt"), "", m.createSession), nil
 
 		case "d":
 			return m, m.detachSession()
 
+		case "p":
+			if m.selected >= 0 && m.selected < len(m.sessions) {
+				return m.showProcesses(m.sessions[m.selected].Name)
+			}
+
+		case "f":
+			return m.toggleDirtyFilter()
+
+		case "i":
+			return m.showStale()
+
+		case "P":
+			if m.selected >= 0 && m.selected < len(m.sessions) {
+				return m, m.togglePinned(m.sessions[m.selected])
+			}
+
+		case "H":
+			if m.selected >= 0 && m.selected < len(m.sessions) {
+				return m.hibernate(m.sessions[m.selected])
+			}
+
+		case "u":
+			return m.toggleUsage()
+
+		case "s":
+			return m.cycleSort()
+
 		case "x":
 			return m, m.killSession()
 		}
@@ -110,41 +230,232 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 
 	case sessionsLoadedMsg:
-		m.sessions = msg
+		// tmux 按名称排列会话，过滤和排序后按名称重新选中原来的会话
+		sel := m.currentSelection()
 		// 如果刚创建了新会话，选中它
-		if m.newSessionName != "" {
-			for i, session := range m.sessions {
-				if session.Name == m.newSessionName {
-					m.selected = i
-					m.newSessionName = "" // 清空标记
-					break
-				}
+		for _, session := range msg {
+			if m.newSessionName != "" && session.Name == m.newSessionName {
+				sel = selection{session: session.Name}
+				m.newSessionName = "" // 清空标记
 			}
 		}
-		// 确保选中项有效
-		if m.selected >= len(m.sessions) {
-			m.selected = len(m.sessions) - 1
+		m.sessions = msg
+		m, cmd := m.loadGit()
+		return m.filterSessions().sortSessions().restoreSelection(sel), cmd
+
+	case archivesLoadedMsg:
+		sel := m.currentSelection()
+		m.archives = msg
+		return m.restoreSelection(sel), nil
+
+	case archiveActionMsg:
+		m.status = msg.status
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+		}
+		m.newSessionName = msg.session
+		return m, m.loadSessions()
+
+	case gitLoadedMsg:
+		m.gitLoading = false
+		m.gitStatus = msg
+		return m.filterSessions(), nil
+
+	case processesLoadedMsg:
+		if m.view != viewProcesses || msg.target != m.procTarget {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+			return m, nil
+		}
+		m.procTrees = msg.trees
+		if rows := len(m.procRows()); m.procSelected >= rows {
+			m.procSelected = max(rows-1, 0)
 		}
 		return m, nil
 
+	case staleLoadedMsg:
+		if m.view != viewStale {
+			return m, nil
+		}
+		m.staleLoaded = true
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+			return m, nil
+		}
+		m.stale = msg.candidates
+		if m.staleSelected >= len(m.stale) {
+			m.staleSelected = max(len(m.stale)-1, 0)
+		}
+		return m, nil
+
+	case staleActionMsg:
+		m.status = msg.status
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+		}
+		return m, m.loadStale()
+
+	case sessionPinnedMsg:
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+		}
+		return m, m.loadSessions()
+
+	case usageLoadedMsg:
+		if msg.err != nil {
+			m.showUsage = false
+			m.sortKey = proc.SortNone
+			m.status = i18n.T("tui.usage_unavailable", msg.err)
+			return m, nil
+		}
+		if !m.showUsage {
+			return m, nil
+		}
+		m.snapshot = msg.snapshot
+		m.usage = msg.usage
+		return m.sortSessions(), nil
+
 	case sessionAttachedMsg:
 		if msg.err != nil {
 			// 显示错误
 			m.quitting = true
 			return m, tea.Quit
 		}
-		// 保存要附加的会话名，然后退出 TUI
-		m.attachSessionName = msg.name
+		// 保存要连接的目标，然后退出 TUI
+		m.attachTarget = msg.target
+		m.attachOpts = msg.opts
 		m.quitting = true
 		return m, tea.Quit
 
+	case treeLoadedMsg:
+		// 离开窗口视图后才返回的结果（例如定时刷新）直接丢弃
+		if m.view != viewWindows || m.treeSession != msg.session {
+			return m, nil
+		}
+		// 刷新后按目标重新选中原来的行
+		focus := m.treeFocus
+		if focus == "" && m.treeSelected < len(m.tree) {
+			focus = m.tree[m.treeSelected].target()
+		}
+		m.tree = msg.items
+		for i, item := range m.tree {
+			if item.target() == focus {
+				m.treeSelected = i
+			}
+		}
+		m.treeFocus = ""
+		if m.treeSelected >= len(m.tree) {
+			m.treeSelected = max(len(m.tree)-1, 0)
+		}
+		return m, nil
+
+	case treeActionMsg:
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+		} else if msg.status != "" {
+			m.status = msg.status
+		}
+		m.treeFocus = msg.focus
+		return m, m.loadTree(m.treeSession)
+
+	case joinTargetsMsg:
+		// 读取期间离开了窗口视图或打开了其他输入时不再弹出选择器
+		if m.view != viewWindows || m.inputMode || m.picker != nil || m.sendPanes != nil {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+			return m, nil
+		}
+		return m.pickJoinTarget(msg), nil
+
+	case promptSendCommandMsg:
+		return m.promptSendCommand(msg.pattern), nil
+
+	case sendPreviewMsg:
+		switch {
+		case msg.err != nil:
+			m.status = i18n.T("tui.action_failed", msg.err)
+		case len(msg.panes) == 0:
+			m.status = i18n.T("tui.send_no_targets", msg.pattern)
+		default:
+			m.sendPanes = msg.panes
+			m.sendCommand = msg.command
+		}
+		return m, nil
+
+	case sendDoneMsg:
+		m.status = i18n.N("tui.send_done", msg.sent)
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+		}
+		return m, nil
+
+	case searchStartMsg:
+		m.view = viewSearch
+		m.searchQuery = msg.query
+		m.searching = true
+		m.matches = nil
+		m.matchSelected = 0
+		m.status = ""
+		return m, m.runSearch(msg.query)
+
+	case searchResultsMsg:
+		if msg.query != m.searchQuery {
+			return m, nil // 已经开始了新的搜索
+		}
+		m.searching = false
+		m.matches = msg.matches
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+		}
+		return m, nil
+
+	case matchShownMsg:
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+			return m, nil
+		}
+		return m, attachTo(msg.target, tmux.AttachOptions{})
+
+	case refreshMsg:
+		return m, tea.Batch(m.refresh(), refreshTicker())
+
+	case paneMarksMsg:
+		m.recording = msg.recording
+		m.finished = msg.finished
+		return m, nil
+
+	case exportDoneMsg:
+		m.status = msg.status
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+		}
+		return m, nil
+
+	case clientsLoadedMsg:
+		m.clients = msg
+		if m.clientSelected >= len(m.clients) {
+			m.clientSelected = max(len(m.clients)-1, 0)
+		}
+		return m, nil
+
+	case clientActionMsg:
+		m.status = ""
+		if msg.err != nil {
+			m.status = i18n.T("tui.action_failed", msg.err)
+		}
+		return m, tea.Batch(m.loadClients(), m.loadSessions())
+
 	case sessionDetachedMsg:
 		return m, m.loadSessions()
 
 	case sessionCreatedMsg:
 		if msg.err != nil {
 			// 创建失败，显示错误并返回列表
-			fmt.Printf("\n创建会话失败: %v\n", msg.err)
+			fmt.Printf("\n%s\n", i18n.T("tui.create_failed", msg.err))
 			return m, tea.Quit
 		}
 		// 创建成功，保存会话名并刷新列表
@@ -162,9 +473,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) handleInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
-		if m.inputBuffer != "" {
+		if m.inputBuffer != "" || m.inputOptional {
 			m.inputMode = false
-			return m, m.createSession(m.inputBuffer)
+			return m, m.inputSubmit(m.inputBuffer)
 		}
 		m.inputMode = false
 		return m, nil
@@ -174,15 +485,20 @@ func (m Model) handleInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.inputBuffer = ""
 		return m, nil
 
+	case "tab":
+		if m.inputTab != nil {
+			m = m.inputTab(m)
+		}
+
 	case "ctrl+h", "backspace":
-		if len(m.inputBuffer) > 0 {
-			m.inputBuffer = m.inputBuffer[:len(m.inputBuffer)-1]
+		if runes := []rune(m.inputBuffer); len(runes) > 0 {
+			m.inputBuffer = string(runes[:len(runes)-1])
 		}
 
 	default:
-		// 添加字符到缓冲区
-		if len(msg.String()) == 1 {
-			m.inputBuffer += msg.String()
+		// 添加字符到缓冲区（包括中文和粘贴的多个字符）
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.inputBuffer += string(msg.Runes)
 		}
 	}
 
@@ -195,11 +511,30 @@ func (m Model) View() string {
 		return ""
 	}
 
-	// 输入模式
+	// 选择器和输入模式
+	if m.picker != nil {
+		return m.renderPicker()
+	}
+	if m.sendPanes != nil {
+		return m.renderSendPreview()
+	}
 	if m.inputMode {
 		return m.renderInput()
 	}
 
+	switch m.view {
+	case viewWindows:
+		return m.renderTree()
+	case viewClients:
+		return m.renderClients()
+	case viewSearch:
+		return m.renderSearch()
+	case viewProcesses:
+		return m.renderProcesses()
+	case viewStale:
+		return m.renderStale()
+	}
+
 	// 正常模式
 	return m.renderNormal()
 }
@@ -209,13 +544,20 @@ func (m Model) renderNormal() string {
 	var b strings.Builder
 
 	// 标题
-	title := titleStyle.Render("Tmux 会话管理")
+	title := titleStyle.Render(i18n.T("tui.title"))
+	if m.dirtyOnly {
+		title = titleStyle.Render(i18n.T("tui.title_dirty"))
+	}
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
 	// 会话列表
 	if len(m.sessions) == 0 {
-		b.WriteString(itemStyle.Render("没有会话，按 n 新建会话"))
+		empty := i18n.T("tui.no_sessions")
+		if m.dirtyOnly {
+			empty = i18n.T("tui.no_dirty_sessions")
+		}
+		b.WriteString(itemStyle.Render(empty))
 		b.WriteString("\n")
 	} else {
 		for i, session := range m.sessions {
@@ -228,31 +570,68 @@ func (m Model) renderNormal() string {
 			indicator := "  "
 			if session.Attached {
 				indicator = activeIndicator
+				// 选中行整体使用 selectedStyle，内嵌样式会重置背景色
+				if i != m.selected {
+					indicator = accentStyle.Render(activeIndicator)
+				}
 			}
 
 			timeInfo := formatTime(session.Created)
 
-			line := fmt.Sprintf("%s%s%s (%s)",
+			line := fmt.Sprintf("%s%s%s%s (%s)",
 				indicator,
 				session.Name,
-				strings.Repeat(" ", 40-len(session.Name)),
+				strings.Repeat(" ", max(40-len(session.Name), 1)),
+				m.usageColumns(m.usage.Sessions[session.Name]),
 				timeInfo,
 			)
+			line += m.gitBadge(session, i == m.selected)
+			line += m.pinnedBadge(session, i == m.selected)
+			if badges := alertBadges(session.Alerts); badges != "" {
+				if i != m.selected {
+					badges = accentStyle.Render(badges)
+				}
+				line += " " + badges
+			}
+			if m.recording[session.Name] > 0 {
+				rec := i18n.T("tui.recording")
+				if i != m.selected {
+					rec = accentStyle.Render(rec)
+				}
+				line += " " + rec
+			}
+			if m.finished[session.Name] > 0 {
+				done := i18n.T("tui.watch_done")
+				if i != m.selected {
+					done = accentStyle.Render(done)
+				}
+				line += " " + done
+			}
 
 			b.WriteString(style.Render(line))
 			b.WriteString("\n")
 		}
 	}
+	m.renderArchives(&b)
 
 	b.WriteString("\n")
+	if m.status != "" {
+		b.WriteString(accentStyle.Render(" " + m.status))
+		b.WriteString("\n")
+	}
 
 	// 快捷键提示
-	hints := "[Enter]进入 [d]断开 [n]新建 [x]删除 [q]退出"
+	hints := i18n.T("tui.hints")
 	b.WriteString(hintStyle.Render(hints))
 	b.WriteString("\n")
+	b.WriteString(hintStyle.Render(i18n.T("tui.attach_hints")))
+	b.WriteString("\n")
 
 	// 额外提示：如何退出 tmux 会话
-	tip := "💡 提示：进入会话后按 Ctrl+b d 可退出但保持会话运行"
+	tip := i18n.T("tui.tip_detach")
+	if m.popup {
+		tip = i18n.T("tui.tip_popup")
+	}
 	b.WriteString(hintStyle.Render(tip))
 
 	return b.String()
@@ -263,12 +642,12 @@ func (m Model) renderInput() string {
 	var b strings.Builder
 
 	// 标题
-	title := titleStyle.Render("新建会话")
+	title := titleStyle.Render(m.inputTitle)
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
 	// 输入提示
-	b.WriteString(itemStyle.Render("请输入会话名称:"))
+	b.WriteString(itemStyle.Render(m.inputPrompt))
 	b.WriteString("\n\n")
 
 	// 输入框
@@ -278,38 +657,77 @@ func (m Model) renderInput() string {
 	b.WriteString("\n\n")
 
 	// 快捷键提示
-	hints := "[Enter]确认 [Esc]取消"
+	hints := i18n.T("tui.input_hints")
 	b.WriteString(hintStyle.Render(hints))
 
 	return b.String()
 }
 
+// selection 记录会话列表中选中的行：会话按名称，休眠的会话按存档目录
+// 列表刷新后按它重新定位，避免选中项随会话的增减或排序移到别的会话上
+type selection struct {
+	session string
+	archive string
+}
+
+// currentSelection 返回当前选中的行
+func (m Model) currentSelection() selection {
+	if m.selected >= 0 && m.selected < len(m.sessions) {
+		return selection{session: m.sessions[m.selected].Name}
+	}
+	if s, ok := m.selectedArchive(); ok {
+		return selection{archive: s.Dir}
+	}
+	return selection{}
+}
+
+// restoreSelection 重新选中 sel，找不到时只保证选中项有效
+func (m Model) restoreSelection(sel selection) Model {
+	for i, s := range m.sessions {
+		if sel.session != "" && s.Name == sel.session {
+			m.selected = i
+			return m
+		}
+	}
+	for i, s := range m.archives {
+		if sel.archive != "" && s.Dir == sel.archive {
+			m.selected = len(m.sessions) + i
+			return m
+		}
+	}
+	if m.selected >= m.rowCount() || m.selected < 0 {
+		m.selected = m.rowCount() - 1
+	}
+	return m
+}
+
 // Commands
 
 func (m Model) loadSessions() tea.Cmd {
-	return func() tea.Msg {
+	return tea.Batch(func() tea.Msg {
 		sessions, err := m.manager.ListSessions()
 		if err != nil {
 			return sessionsLoadedMsg{}
 		}
 		return sessionsLoadedMsg(sessions)
-	}
+	}, m.loadPaneMarks(), m.loadUsage(), m.loadArchives())
 }
 
-func (m Model) attachSession() tea.Cmd {
-	return func() tea.Msg {
-		if m.selected >= len(m.sessions) {
+func (m Model) attachSession(opts tmux.AttachOptions) tea.Cmd {
+	if m.selected < 0 || m.selected >= len(m.sessions) {
+		return func() tea.Msg {
 			return sessionAttachedMsg{err: fmt.Errorf("no session selected")}
 		}
-		session := m.sessions[m.selected]
+	}
+	return attachTo(m.sessions[m.selected].Name, opts)
+}
 
-		// 注意：我们不在这里直接调用 AttachSession
-		// 因为它会阻塞并接管终端
-		// 我们只返回会话名，让 main 函数处理
-		return sessionAttachedMsg{
-			err:  nil,
-			name: session.Name,
-		}
+// attachTo 返回连接目标的消息
+// 注意：我们不在这里直接连接，因为在 tmux 外连接会接管终端
+// 我们只返回目标，让 main 函数在 TUI 退出后处理
+func attachTo(target string, opts tmux.AttachOptions) tea.Cmd {
+	return func() tea.Msg {
+		return sessionAttachedMsg{target: target, opts: opts}
 	}
 }
 
@@ -343,19 +761,51 @@ func (m Model) killSession() tea.Cmd {
 	}
 }
 
-// AttachSessionName 返回要附加的会话名称
-func (m Model) AttachSessionName() string {
-	return m.attachSessionName
+// AttachTarget 返回要连接的目标和连接方式，目标为空表示不需要连接
+func (m Model) AttachTarget() (string, tmux.AttachOptions) {
+	return m.attachTarget, m.attachOpts
+}
+
+// WithPopup 标记 TUI 是否运行在 display-popup 中
+func (m Model) WithPopup(popup bool) Model {
+	m.popup = popup
+	return m
+}
+
+// WithRecording 设置窗格录制的目录和格式
+func (m Model) WithRecording(opts record.Options) Model {
+	m.recordOpts = opts
+	return m
+}
+
+// WithExportDir 设置滚动历史导出目录
+func (m Model) WithExportDir(dir string) Model {
+	m.exportDir = dir
+	return m
+}
+
+// WithOutput 设置界面的输出，需要与 tea.WithOutput 使用同一个
+func (m Model) WithOutput(out io.Writer) Model {
+	m.output = out
+	return m
+}
+
+// WithReaper 设置清理空闲会话的策略和存档目录
+func (m Model) WithReaper(policy reap.Policy, archiveDir string) Model {
+	m.reapPolicy = policy
+	m.archiveDir = archiveDir
+	return m
 }
 
 // NewModel 创建新的 Model
 func NewModel() Model {
 	return Model{
-		sessions: make([]tmux.Session, 0),
-		selected: 0,
-		manager:  tmux.NewManager(),
-		quitting: false,
-		inputMode: false,
+		sessions:    make([]tmux.Session, 0),
+		selected:    0,
+		manager:     tmux.NewManager(),
+		gitCache:    git.NewCache(gitCacheTTL),
+		quitting:    false,
+		inputMode:   false,
 		inputBuffer: "",
 	}
 }
@@ -365,13 +815,13 @@ func formatTime(t time.Time) string {
 	duration := time.Since(t)
 
 	if duration < time.Minute {
-		return "刚刚"
+		return i18n.T("time.just_now")
 	} else if duration < time.Hour {
-		return fmt.Sprintf("%d分钟前", int(duration.Minutes()))
+		return i18n.N("time.minutes_ago", int(duration.Minutes()))
 	} else if duration < 24*time.Hour {
-		return fmt.Sprintf("%d小时前", int(duration.Hours()))
+		return i18n.N("time.hours_ago", int(duration.Hours()))
 	} else if duration < 30*24*time.Hour {
-		return fmt.Sprintf("%d天前", int(duration.Hours()/24))
+		return i18n.N("time.days_ago", int(duration.Hours()/24))
 	}
-	return t.Format("2006-01-02")
+	return t.Format(i18n.T("time.date_layout"))
 }
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/proc"
+	"github.com/charmbracelet/bubbletea"
+)
+
+// usageSampleInterval 是第一次统计时计算 CPU 占用的采样间隔，之后与上一次刷新的快照比较
+const usageSampleInterval = 500 * time.Millisecond
+
+type usageLoadedMsg struct {
+	snapshot *proc.Snapshot
+	usage    proc.Resources
+	err      error
+}
+
+// loadUsage 统计每个窗格、窗口和会话的 CPU 和内存占用，未显示资源列时不读取
+func (m Model) loadUsage() tea.Cmd {
+	if !m.showUsage {
+		return nil
+	}
+	prev := m.snapshot
+	return func() tea.Msg {
+		panes, err := m.manager.ListPanes("")
+		if err != nil {
+			return usageLoadedMsg{err: err}
+		}
+		var cur *proc.Snapshot
+		if prev == nil {
+			cur, prev, err = proc.Sample(usageSampleInterval)
+		} else {
+			cur, err = proc.Take()
+		}
+		if err != nil {
+			return usageLoadedMsg{err: err}
+		}
+		return usageLoadedMsg{snapshot: cur, usage: proc.Collect(panes, cur, prev)}
+	}
+}
+
+// toggleUsage 显示或隐藏资源列，隐藏时同时取消按资源排序
+func (m Model) toggleUsage() (Model, tea.Cmd) {
+	m.showUsage = !m.showUsage
+	if m.showUsage {
+		return m, m.loadUsage()
+	}
+	m.snapshot = nil
+	if m.sortKey != proc.SortNone {
+		m.sortKey = proc.SortNone
+		return m, m.loadSessions()
+	}
+	return m, nil
+}
+
+// cycleSort 在默认顺序、按 CPU、按内存排序之间切换，按资源排序时显示资源列
+func (m Model) cycleSort() (Model, tea.Cmd) {
+	switch m.sortKey {
+	case proc.SortNone:
+		m.sortKey = proc.SortCPU
+		m.status = i18n.T("tui.sort_cpu")
+	case proc.SortCPU:
+		m.sortKey = proc.SortRSS
+		m.status = i18n.T("tui.sort_rss")
+	default:
+		m.sortKey = proc.SortNone
+		m.status = i18n.T("tui.sort_default")
+		// 重新加载以恢复 tmux 的顺序
+		return m, m.loadSessions()
+	}
+	if !m.showUsage {
+		m.showUsage = true
+		return m, m.loadUsage()
+	}
+	return m.sortSessions(), nil
+}
+
+// sortSessions 按资源占用重新排列会话，保持选中的会话不变
+func (m Model) sortSessions() Model {
+	if m.sortKey == proc.SortNone || m.usage.Sessions == nil {
+		return m
+	}
+	sel := m.currentSelection()
+	m.sessions = slices.Clone(m.sessions)
+	m.usage.SortSessions(m.sessions, m.sortKey)
+	return m.restoreSelection(sel)
+}
+
+// usageColumns 返回资源列，未显示资源列时为空
+func (m Model) usageColumns(u proc.Usage) string {
+	if !m.showUsage {
+		return ""
+	}
+	return fmt.Sprintf("%7s %6s ", proc.FormatCPU(u.CPU), proc.FormatRSS(u.RSS))
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/DreamCats/tmuxmanager/internal/watch"
+	"github.com/charmbracelet/bubbletea"
+)
+
+// paneMarksMsg 是每个会话中正在录制、已经结束等待的窗格数
+type paneMarksMsg struct {
+	recording map[string]int
+	finished  map[string]int
+}
+
+// loadPaneMarks 统计每个会话中窗格的录制和等待状态，用于会话列表的标记
+func (m Model) loadPaneMarks() tea.Cmd {
+	return func() tea.Msg {
+		msg := paneMarksMsg{recording: map[string]int{}, finished: map[string]int{}}
+		panes, err := m.manager.ListPanes("")
+		if err != nil {
+			return msg
+		}
+		for _, p := range panes {
+			if p.Recording != "" {
+				msg.recording[p.Session]++
+			}
+			if p.Finished != "" {
+				msg.finished[p.Session]++
+			}
+		}
+		return msg
+	}
+}
+
+// paneMarks 返回窗格的录制和等待标记，例如 [rec] [✓ make 3m12s]
+func paneMarks(p tmux.Pane) string {
+	var marks string
+	if p.Recording != "" {
+		marks += " " + i18n.T("tui.recording")
+	}
+	if p.Watching {
+		marks += " " + i18n.T("tui.watching")
+	} else if r, ok := watch.ParseResult(p.Finished); ok {
+		if r.Status > 0 {
+			marks += " " + i18n.T("tui.watch_failed", r.Command, r.Status, watch.FormatDuration(r.Duration))
+		} else {
+			marks += " " + i18n.T("tui.watch_finished", r.Command, watch.FormatDuration(r.Duration))
+		}
+	}
+	return marks
+}
+
+// toggleWatch 等待选中窗格中的命令结束；已经在等待或已经结束时取消等待并清除标记
+func (m Model) toggleWatch() tea.Cmd {
+	target := m.tree[m.treeSelected].target()
+	return func() tea.Msg {
+		pane, err := m.activePane(target)
+		if err != nil || pane == nil {
+			return treeActionMsg{err: err, focus: target}
+		}
+		if pane.Watching || pane.Finished != "" {
+			return treeActionMsg{err: watch.Stop(m.manager, *pane), focus: target}
+		}
+		err = watch.Start(m.manager, *pane)
+		return treeActionMsg{err: err, focus: target, status: i18n.T("tui.watch_started", pane.Command)}
+	}
+}
@@ -0,0 +1,360 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DreamCats/tmuxmanager/internal/i18n"
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+	"github.com/charmbracelet/bubbletea"
+)
+
+// treeItem 是窗口视图中的一行：窗口，或窗口下的窗格
+type treeItem struct {
+	window tmux.Window
+	pane   *tmux.Pane
+	marks  string // 单窗格窗口的窗格的录制、等待标记（窗格行不显示）
+}
+
+// target 返回该行对应的 tmux 目标
+func (t treeItem) target() string {
+	if t.pane != nil {
+		return t.pane.Target()
+	}
+	return t.window.Target()
+}
+
+type treeLoadedMsg struct {
+	session string
+	items   []treeItem
+}
+type joinTargetsMsg struct {
+	pane    string // 要移动的窗格
+	window  string // 窗格所在的窗口，不作为目标
+	windows []tmux.Window
+	err     error
+}
+type treeActionMsg struct {
+	err    error
+	focus  string
+	status string // 成功时显示的提示
+}
+
+// treeAction 执行窗口操作，完成后刷新窗口视图并选中 focus
+func treeAction(focus string, action func() error) tea.Cmd {
+	return func() tea.Msg {
+		return treeActionMsg{err: action(), focus: focus}
+	}
+}
+
+// neighbor 返回与选中窗口相邻的窗口，step 为 -1（上一个）或 1（下一个）
+func (m Model) neighbor(step int) (tmux.Window, bool) {
+	current := m.tree[m.treeSelected].window
+	for i := m.treeSelected + step; i >= 0 && i < len(m.tree); i += step {
+		if w := m.tree[i].window; m.tree[i].pane == nil && w.Index != current.Index {
+			return w, true
+		}
+	}
+	return tmux.Window{}, false
+}
+
+// showTree 打开会话的窗口视图
+func (m Model) showTree(session string) (Model, tea.Cmd) {
+	m.view = viewWindows
+	m.treeSession = session
+	m.tree = nil
+	m.treeSelected = 0
+	m.treeFocus = ""
+	m.status = ""
+	return m, m.loadTree(session)
+}
+
+// loadTree 读取会话的窗口和窗格，多窗格的窗口在其下列出窗格
+func (m Model) loadTree(session string) tea.Cmd {
+	return func() tea.Msg {
+		windows, err := m.manager.ListWindows(session)
+		if err != nil {
+			return treeLoadedMsg{session: session}
+		}
+		panes, _ := m.manager.ListPanes(session)
+
+		var items []treeItem
+		for _, w := range windows {
+			if w.Panes < 2 {
+				item := treeItem{window: w}
+				for _, p := range panes {
+					if p.Window == w.Index {
+						item.marks = paneMarks(p)
+					}
+				}
+				items = append(items, item)
+				continue
+			}
+			items = append(items, treeItem{window: w})
+			for i := range panes {
+				if panes[i].Window == w.Index {
+					items = append(items, treeItem{window: w, pane: &panes[i]})
+				}
+			}
+		}
+		return treeLoadedMsg{session: session, items: items}
+	}
+}
+
+// handleTree 处理窗口视图的按键
+func (m Model) handleTree(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+	if m.treeSelected < len(m.tree) {
+		if model, cmd, ok := m.handlePaneKey(msg.String()); ok {
+			return model, cmd
+		}
+		if model, cmd, ok := m.handleWindowKey(msg.String()); ok {
+			return model, cmd
+		}
+		if model, cmd, ok := m.handleMonitorKey(msg.String()); ok {
+			return model, cmd
+		}
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		m.quitting = true
+		return m, tea.Quit
+
+	case "esc", "w", "backspace":
+		m.view = viewSessions
+		return m, m.loadSessions()
+
+	case "up", "k":
+		if m.treeSelected > 0 {
+			m.treeSelected--
+		}
+
+	case "down", "j":
+		if m.treeSelected < len(m.tree)-1 {
+			m.treeSelected++
+		}
+
+	default:
+		if opts, ok := attachKeys[msg.String()]; ok && m.treeSelected < len(m.tree) {
+			return m, attachTo(m.tree[m.treeSelected].target(), opts)
+		}
+	}
+	return m, nil
+}
+
+// loadJoinTargets 读取所有会话的窗口，作为移动窗格的目标
+func (m Model) loadJoinTargets(pane, window string) tea.Cmd {
+	return func() tea.Msg {
+		windows, err := m.manager.ListWindows("")
+		return joinTargetsMsg{pane: pane, window: window, windows: windows, err: err}
+	}
+}
+
+// pickJoinTarget 选择目标窗口，将窗格移动过去
+func (m Model) pickJoinTarget(msg joinTargetsMsg) Model {
+	var targets, labels []string
+	for _, w := range msg.windows {
+		if w.Target() == msg.window {
+			continue
+		}
+		targets = append(targets, w.Target())
+		labels = append(labels, fmt.Sprintf("%s  %s", w.Target(), w.Name))
+	}
+	m = m.startPicker(i18n.T("tui.join_pane_title", msg.pane), targets, "",
+		func(dst string) tea.Cmd {
+			return treeAction("", func() error { return m.manager.JoinPane(msg.pane, dst) })
+		})
+	if m.picker != nil {
+		m.picker.labels = labels
+	}
+	return m
+}
+
+// handlePaneKey 处理针对选中窗格的操作，选中窗口行时作用于窗口的当前窗格
+// ok 为 false 表示不是窗格操作的按键
+func (m Model) handlePaneKey(key string) (tea.Model, tea.Cmd, bool) {
+	item := m.tree[m.treeSelected]
+	target := item.target()
+
+	switch key {
+	case "%", "\"":
+		horizontal := key == "%"
+		m = m.startInput(i18n.T("tui.split_title"), i18n.T("tui.split_prompt"), "",
+			func(command string) tea.Cmd {
+				return treeAction(target, func() error { return m.manager.SplitPane(target, horizontal, command) })
+			})
+		m.inputOptional = true
+		return m, nil, true
+
+	case "x":
+		// 窗格行关闭窗格，窗口行交给 handleWindowKey 关闭窗口
+		if item.pane == nil {
+			return m, nil, false
+		}
+		return m, treeAction("", func() error { return m.manager.KillPane(target) }), true
+
+	case "z":
+		return m, treeAction(target, func() error { return m.manager.ZoomPane(target) }), true
+
+	case "R":
+		return m, treeAction(target, func() error { return m.manager.RespawnPane(target) }), true
+
+	case "!":
+		return m, treeAction("", func() error { return m.manager.BreakPane(target) }), true
+
+	case ">":
+		return m, m.loadJoinTargets(target, item.window.Target()), true
+
+	case "S":
+		return m.promptSendCommand(target), nil, true
+
+	case "E":
+		return m.promptExport(target), nil, true
+
+	case "o":
+		return m, m.toggleRecording(), true
+
+	case "W":
+		return m, m.toggleWatch(), true
+
+	case "p":
+		model, cmd := m.showProcesses(target)
+		return model, cmd, true
+
+	case "s":
+		w := item.window
+		return m, treeAction(target, func() error { return m.manager.SetSynchronize(w.Target(), !w.Synchronized) }), true
+	}
+	return m, nil, false
+}
+
+// handleWindowKey 处理针对选中窗口的操作，ok 为 false 表示不是窗口操作的按键
+func (m Model) handleWindowKey(key string) (tea.Model, tea.Cmd, bool) {
+	session := m.treeSession
+	w := m.tree[m.treeSelected].window
+
+	switch key {
+	case "n":
+		return m.startInput(i18n.T("tui.new_window_title"), i18n.T("tui.window_name_prompt"), "",
+			func(name string) tea.Cmd {
+				return treeAction("", func() error { return m.manager.NewWindow(session, name) })
+			}), nil, true
+
+	case "e":
+		return m.startInput(i18n.T("tui.rename_window_title"), i18n.T("tui.window_name_prompt"), w.Name,
+			func(name string) tea.Cmd {
+				return treeAction(w.Target(), func() error { return m.manager.RenameWindow(w.Target(), name) })
+			}), nil, true
+
+	case "x":
+		return m, treeAction("", func() error { return m.manager.KillWindow(w.Target()) }), true
+
+	case "K", "J":
+		step := -1
+		if key == "J" {
+			step = 1
+		}
+		other, ok := m.neighbor(step)
+		if !ok {
+			return m, nil, true
+		}
+		return m, treeAction(other.Target(), func() error { return m.manager.SwapWindow(w.Target(), other.Target()) }), true
+
+	case "i":
+		return m.startInput(i18n.T("tui.move_index_title"), i18n.T("tui.move_index_prompt"), "",
+			func(index string) tea.Cmd {
+				dst := session + ":" + index
+				return treeAction(dst, func() error { return m.manager.MoveWindow(w.Target(), dst) })
+			}), nil, true
+
+	case "m":
+		return m.startPicker(i18n.T("tui.move_window_title", w.Name), m.sessionNames(session), "",
+			func(dst string) tea.Cmd {
+				return treeAction("", func() error { return m.manager.MoveWindow(w.Target(), dst+":") })
+			}), nil, true
+
+	case "l":
+		return m.startPicker(i18n.T("tui.link_window_title", w.Name), m.sessionNames(session), "",
+			func(dst string) tea.Cmd {
+				return treeAction(w.Target(), func() error { return m.manager.LinkWindow(w.Target(), dst) })
+			}), nil, true
+
+	case "N":
+		return m, treeAction("", func() error { return m.manager.RenumberWindows(session) }), true
+	}
+	return m, nil, false
+}
+
+// renderTree 渲染窗口视图
+func (m Model) renderTree() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(i18n.T("tui.windows_title", m.treeSession)))
+	b.WriteString("\n\n")
+
+	for i, item := range m.tree {
+		style := itemStyle
+		if i == m.treeSelected {
+			style = selectedStyle
+		}
+
+		var line string
+		if item.pane != nil {
+			p := item.pane
+			line = fmt.Sprintf("      %d.%d  %-12s %s%s", p.Window, p.Index, p.Command, m.usageColumns(m.usage.Panes[p.ID]), p.Path)
+			if p.Dead {
+				line += " " + i18n.T("tui.pane_dead")
+			}
+			line += paneMarks(*p)
+		} else {
+			w := item.window
+			indicator := "  "
+			if w.Active {
+				indicator = activeIndicator
+				if i != m.treeSelected {
+					indicator = accentStyle.Render(activeIndicator)
+				}
+			}
+			name := fmt.Sprintf("%d: %s", w.Index, w.Name)
+			line = fmt.Sprintf("%s%s%s%s (%s)",
+				indicator,
+				name,
+				strings.Repeat(" ", max(40-len(name), 1)),
+				m.usageColumns(m.usage.Windows[w.Target()]),
+				i18n.N("tui.panes", w.Panes),
+			)
+			if w.Synchronized {
+				line += " " + i18n.T("tui.window_sync")
+			}
+			line += item.marks
+			if w.MonitorActivity {
+				line += " " + i18n.T("tui.monitor_activity")
+			}
+			if w.MonitorSilence > 0 {
+				line += " " + i18n.T("tui.monitor_silence", w.MonitorSilence)
+			}
+			if badges := alertBadges(w.Alerts); badges != "" {
+				if i != m.treeSelected {
+					badges = accentStyle.Render(badges)
+				}
+				line += " " + badges
+			}
+		}
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.status != "" {
+		b.WriteString(accentStyle.Render(" " + m.status))
+		b.WriteString("\n")
+	}
+	b.WriteString(hintStyle.Render(i18n.T("tui.tree_hints")))
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render(i18n.T("tui.window_hints")))
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render(i18n.T("tui.pane_hints")))
+
+	return b.String()
+}
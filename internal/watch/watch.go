@@ -0,0 +1,236 @@
+// Package watch 等待窗格的前台命令结束，记录结束时间和耗时
+package watch
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DreamCats/tmuxmanager/internal/tmux"
+)
+
+const (
+	watchOption    = "@tmx_watch"
+	finishedOption = "@tmx_finished"
+	pollInterval   = time.Second
+)
+
+// Result 是一次命令结束的记录
+type Result struct {
+	Command  string
+	Status   int // 退出码，-1 表示未知（shell 中运行的命令拿不到退出码，只有窗格退出时才有）
+	Duration time.Duration
+	Ended    time.Time
+}
+
+// encode 将结果编码为窗格选项的值：退出码|秒数|结束时间|命令
+// 命令可能包含 |（例如管道），放在最后，解析时只拆分前三个字段
+func (r Result) encode() string {
+	return fmt.Sprintf("%d|%d|%d|%s", r.Status, int(r.Duration.Seconds()), r.Ended.Unix(), r.Command)
+}
+
+// ParseResult 解析窗格的结束记录（tmux.Pane.Finished）
+func ParseResult(s string) (Result, bool) {
+	parts := strings.SplitN(s, "|", 4)
+	if len(parts) != 4 {
+		return Result{}, false
+	}
+	status, err1 := strconv.Atoi(parts[0])
+	seconds, err2 := strconv.Atoi(parts[1])
+	ended, err3 := strconv.ParseInt(parts[2], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return Result{}, false
+	}
+	return Result{
+		Command:  parts[3],
+		Status:   status,
+		Duration: time.Duration(seconds) * time.Second,
+		Ended:    time.Unix(ended, 0),
+	}, true
+}
+
+// FormatDuration 以紧凑形式显示耗时，例如 45s、3m12s、2h05m、3d04h
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dd%02dh", int(d.Hours())/24, int(d.Hours())%24)
+}
+
+// Start 开始等待窗格的前台命令结束
+// 由 tmux 在后台运行 tmx __watch，客户端断开后继续等待
+func Start(m *tmux.Manager, p tmux.Pane) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if err := m.SetPaneOption(p.ID, finishedOption, ""); err != nil {
+		return err
+	}
+	if err := m.SetPaneOption(p.ID, watchOption, strconv.FormatInt(time.Now().Unix(), 10)); err != nil {
+		return err
+	}
+	// __watch 发现没有标记时会立即退出，所以先设置标记，启动失败时再清除
+	if err := m.RunShell(fmt.Sprintf("exec %s __watch %s", tmux.ShellQuote(exe), p.ID)); err != nil {
+		m.SetPaneOption(p.ID, watchOption, "")
+		return err
+	}
+	return nil
+}
+
+// Stop 停止等待并清除结束记录，后台的 tmx __watch 在下次检查时退出
+func Stop(m *tmux.Manager, p tmux.Pane) error {
+	if err := m.SetPaneOption(p.ID, watchOption, ""); err != nil {
+		return err
+	}
+	return m.SetPaneOption(p.ID, finishedOption, "")
+}
+
+// Run 每秒检查一次窗格，前台命令结束、窗格退出或被重新启动时调用 done 并返回
+// 只等待一条命令：开始时窗格空闲则等待下一条命令开始
+func Run(m *tmux.Manager, paneID string, done func(tmux.Pane, Result)) error {
+	var last tmux.Pane
+	command, started := "", time.Now()
+	own := false // 窗格自身的进程就是要等待的命令（不是 shell），等待窗格退出
+	for {
+		p, ok, err := m.FindPane(paneID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// 窗格已关闭，无法再留下标记，只发出提醒
+			if command != "" {
+				done(last, finish(command, -1, started))
+			}
+			return nil
+		}
+		if !p.Watching {
+			return nil
+		}
+		if last.ID == "" {
+			last = p
+			// 窗格进程自己在前台且不是 shell，例如 new-window 'make'
+			if !IsShell(p.Command) && foreground(p.PID) == p.PID {
+				own = true
+				command, started = p.Command, time.Now().Add(-elapsed(p.PID))
+			}
+		}
+
+		var result *Result
+		switch {
+		case p.Dead && !last.Dead:
+			// 管道关闭时窗格就显示为已退出，退出码可能还没有记录，下一次检查时再读取
+		case p.Dead:
+			r := finish(valueOr(command, last.Command), p.DeadStatus, started)
+			result = &r
+		case p.PID != last.PID:
+			r := finish(valueOr(command, last.Command), -1, started)
+			result = &r
+		case own:
+		default:
+			// 前台进程组不是 shell 自己时说明有命令在运行
+			fg := foreground(p.PID)
+			if fg > 0 && fg != p.PID {
+				if command == "" {
+					command = p.Command
+					started = time.Now().Add(-elapsed(fg))
+				}
+			} else if command != "" {
+				r := finish(command, -1, started)
+				result = &r
+			}
+		}
+
+		if result != nil {
+			m.SetPaneOption(p.ID, watchOption, "")
+			m.SetPaneOption(p.ID, finishedOption, result.encode())
+			done(p, *result)
+			return nil
+		}
+		last = p
+		time.Sleep(pollInterval)
+	}
+}
+
+// Recent 返回最近 within 时间内结束的记录中最新的一条
+func Recent(panes []tmux.Pane, within time.Duration) (tmux.Pane, Result, bool) {
+	var latest tmux.Pane
+	var result Result
+	found := false
+	for _, p := range panes {
+		r, ok := ParseResult(p.Finished)
+		if ok && time.Since(r.Ended) <= within && (!found || r.Ended.After(result.Ended)) {
+			latest, result, found = p, r, true
+		}
+	}
+	return latest, result, found
+}
+
+// shells 是常见的交互式 shell，窗格中运行其它程序时视为等待该程序本身
+var shells = []string{"sh", "bash", "zsh", "fish", "dash", "ksh", "mksh", "tcsh", "csh", "nu", "xonsh", "elvish", "pwsh"}
+
+// IsShell 返回窗格的当前命令是否是交互式 shell
+func IsShell(command string) bool {
+	return slices.Contains(shells, strings.TrimPrefix(command, "-"))
+}
+
+func finish(command string, status int, started time.Time) Result {
+	now := time.Now()
+	return Result{Command: command, Status: status, Duration: now.Sub(started), Ended: now}
+}
+
+// foreground 返回进程所在终端的前台进程组 ID
+func foreground(pid int) int {
+	output, err := exec.Command("ps", "-o", "tpgid=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(string(output)))
+	return n
+}
+
+// elapsed 返回进程已运行的时间
+func elapsed(pid int) time.Duration {
+	output, err := exec.Command("ps", "-o", "etime=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return 0
+	}
+	return parseEtime(string(output))
+}
+
+// parseEtime 解析 ps 的 etime，格式为 [[dd-]hh:]mm:ss
+func parseEtime(etime string) time.Duration {
+	etime = strings.TrimSpace(etime)
+	var total time.Duration
+	if days, rest, ok := strings.Cut(etime, "-"); ok {
+		n, _ := strconv.Atoi(days)
+		total += time.Duration(n) * 24 * time.Hour
+		etime = rest
+	}
+	parts := strings.Split(etime, ":")
+	units := []time.Duration{time.Second, time.Minute, time.Hour}
+	for i := range parts {
+		n, _ := strconv.Atoi(parts[len(parts)-1-i])
+		if i < len(units) {
+			total += time.Duration(n) * units[i]
+		}
+	}
+	return total
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,77 @@
+package watch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResultRoundTrip(t *testing.T) {
+	ended := time.Unix(1760000000, 0)
+	tests := []Result{
+		{Command: "make test", Status: 0, Duration: 75 * time.Second, Ended: ended},
+		{Command: "go test ./... | tee log", Status: 1, Duration: 2 * time.Hour, Ended: ended},
+		{Command: "a || b |", Status: -1, Duration: 0, Ended: ended},
+		{Command: "", Status: 130, Duration: time.Second, Ended: ended},
+	}
+	for _, want := range tests {
+		got, ok := ParseResult(want.encode())
+		if !ok || got != want {
+			t.Errorf("ParseResult(%q) = %+v, %v, want %+v", want.encode(), got, ok, want)
+		}
+	}
+}
+
+func TestParseResultInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"0|12|1760000000",
+		"x|12|1760000000|make",
+		"0|1.5|1760000000|make",
+		"0|12|never|make",
+	} {
+		if r, ok := ParseResult(s); ok {
+			t.Errorf("ParseResult(%q) = %+v, want failure", s, r)
+		}
+	}
+}
+
+func TestParseEtime(t *testing.T) {
+	tests := []struct {
+		etime string
+		want  time.Duration
+	}{
+		{"00:07", 7 * time.Second},
+		{"   05:12\n", 5*time.Minute + 12*time.Second},
+		{"01:02:03", time.Hour + 2*time.Minute + 3*time.Second},
+		{"3-04:05:06", 3*24*time.Hour + 4*time.Hour + 5*time.Minute + 6*time.Second},
+		{"12-00:00:00", 12 * 24 * time.Hour},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := parseEtime(tt.etime); got != tt.want {
+			t.Errorf("parseEtime(%q) = %v, want %v", tt.etime, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{1499 * time.Millisecond, "1s"},
+		{45 * time.Second, "45s"},
+		{59500 * time.Millisecond, "1m00s"},
+		{3*time.Minute + 12*time.Second, "3m12s"},
+		{time.Hour, "1h00m"},
+		{2*time.Hour + 5*time.Minute + 40*time.Second, "2h05m"},
+		{24 * time.Hour, "1d00h"},
+		{3*24*time.Hour + 4*time.Hour + 59*time.Minute, "3d04h"},
+	}
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}